@@ -2,7 +2,6 @@ package stream
 
 import (
 	"encoding/json"
-	"errors"
 
 	"github.com/fatih/structs"
 )
@@ -24,32 +23,49 @@ type Activity struct {
 }
 
 // UnmarshalJSON decodes the provided JSON payload into the Activity. It's required
-// because of the custom JSON fields and time formats.
+// because of the custom JSON fields and time formats. Known fields are decoded
+// straight from the payload, while the remaining ones are collected in Extra.
 func (a *Activity) UnmarshalJSON(b []byte) error {
-	var data map[string]any
-	if err := json.Unmarshal(b, &data); err != nil {
-		return err
-	}
-
-	if _, ok := data["to"]; ok {
-		tos := data["to"].([]any)
-		simpleTos := make([]string, len(tos))
-		for i := range tos {
-			switch to := tos[i].(type) {
-			case string:
-				simpleTos[i] = to
-			case []any:
-				tos, ok := to[0].(string)
-				if !ok {
-					return errors.New("invalid format for to targets")
-				}
-				simpleTos[i] = tos
+	return decodeObject(b, func(key string, dec *json.Decoder) error {
+		var err error
+		switch key {
+		case "id":
+			a.ID, err = decodeString(dec)
+		case "actor":
+			a.Actor, err = decodeString(dec)
+		case "verb":
+			a.Verb, err = decodeString(dec)
+		case "object":
+			a.Object, err = decodeString(dec)
+		case "foreign_id":
+			a.ForeignID, err = decodeString(dec)
+		case "target":
+			a.Target, err = decodeString(dec)
+		case "time":
+			a.Time, err = decodeTime(dec)
+		case "origin":
+			a.Origin, err = decodeString(dec)
+		case "to":
+			a.To, err = decodeToTargets(dec)
+		case "score":
+			a.Score, err = decodeFloat(dec)
+		case "score_vars":
+			err = dec.Decode(&a.ScoreVars)
+		default:
+			var v any
+			if err = dec.Decode(&v); err == nil {
+				a.setExtra(key, v)
 			}
 		}
-		data["to"] = simpleTos
-	}
+		return err
+	})
+}
 
-	return a.decode(data)
+func (a *Activity) setExtra(key string, v any) {
+	if a.Extra == nil {
+		a.Extra = make(map[string]any)
+	}
+	a.Extra[key] = v
 }
 
 // MarshalJSON encodes the Activity to a valid JSON bytes slice. It's required because of
@@ -65,20 +81,6 @@ func (a Activity) MarshalJSON() ([]byte, error) {
 	return json.Marshal(data)
 }
 
-func (a *Activity) decode(data map[string]any) error {
-	meta, err := decodeData(data, a)
-	if err != nil {
-		return err
-	}
-	if len(meta.Unused) > 0 {
-		a.Extra = make(map[string]any)
-		for _, k := range meta.Unused {
-			a.Extra[k] = data[k]
-		}
-	}
-	return nil
-}
-
 // baseActivityGroup is the common part of responses obtained from reading normal or enriched aggregated feeds.
 type baseActivityGroup struct {
 	ActivityCount int    `json:"activity_count,omitempty"`
@@ -95,3 +97,13 @@ type ActivityGroup struct {
 	baseActivityGroup
 	Activities []Activity `json:"activities,omitempty"`
 }
+
+// AddTo adds the given feeds to the "to" targets of the activity.
+func (a *Activity) AddTo(ids ...FeedID) {
+	a.To = append(a.To, FeedIDStrings(ids...)...)
+}
+
+// ToFeedIDs parses the "to" targets of the activity.
+func (a Activity) ToFeedIDs() ([]FeedID, error) {
+	return ParseFeedIDs(a.To...)
+}
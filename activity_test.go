@@ -81,3 +81,22 @@ func TestActivityMarshalUnmarshalJSON_toTargets(t *testing.T) {
 		}
 	}
 }
+
+func TestActivityUnmarshalJSON_fields(t *testing.T) {
+	data := []byte(`{"id":"1","actor":"bob","verb":"like","object":"cake","target":null,"time":null,"score":1.5,"score_vars":{"popularity":2},"extra":null}`)
+	var out stream.Activity
+	require.NoError(t, json.Unmarshal(data, &out))
+	assert.Equal(t, stream.Activity{
+		ID:        "1",
+		Actor:     "bob",
+		Verb:      "like",
+		Object:    "cake",
+		Score:     1.5,
+		ScoreVars: map[string]any{"popularity": 2.0},
+		Extra:     map[string]any{"extra": nil},
+	}, out)
+
+	require.Error(t, json.Unmarshal([]byte(`{"actor":42}`), &out))
+	require.Error(t, json.Unmarshal([]byte(`{"time":"not a time"}`), &out))
+	require.Error(t, json.Unmarshal([]byte(`[]`), &out))
+}
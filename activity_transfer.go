@@ -0,0 +1,319 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	defaultExportActivitiesPage = 100
+	maxAddActivities            = 100
+	importForeignIDPrefix       = "import:"
+)
+
+// ExportedActivity is a line written by ExportActivities and read by
+// ImportActivities: an activity along with the feed it was read from.
+type ExportedActivity struct {
+	Feed     FeedID   `json:"feed"`
+	Activity Activity `json:"activity"`
+}
+
+// ExportActivitiesCheckpoint is the position reached by ExportActivities: the
+// feed being exported and the ID of its last exported activity, or group for
+// aggregated and notification feeds. It's meant to be persisted and passed to
+// WithExportActivitiesResume to resume an interrupted export.
+type ExportActivitiesCheckpoint struct {
+	Feed  FeedID `json:"feed"`
+	After string `json:"after,omitempty"`
+	Count int    `json:"count"`
+}
+
+// ExportActivitiesOption configures ExportActivities.
+type ExportActivitiesOption func(*exportActivitiesOptions)
+
+type exportActivitiesOptions struct {
+	pageSize   int
+	copied     bool
+	resume     *ExportActivitiesCheckpoint
+	checkpoint func(ExportActivitiesCheckpoint)
+}
+
+// WithExportActivitiesPageSize sets how many activities are read per
+// request, defaulting to 100.
+func WithExportActivitiesPageSize(size int) ExportActivitiesOption {
+	return func(o *exportActivitiesOptions) {
+		o.pageSize = size
+	}
+}
+
+// WithExportCopiedActivities also exports the activities copied into the
+// feeds through follows, which are skipped by default since importing them
+// would duplicate the originals.
+func WithExportCopiedActivities() ExportActivitiesOption {
+	return func(o *exportActivitiesOptions) {
+		o.copied = true
+	}
+}
+
+// WithExportActivitiesResume resumes an export from the given checkpoint,
+// skipping the feeds listed before the checkpoint's one.
+func WithExportActivitiesResume(cp ExportActivitiesCheckpoint) ExportActivitiesOption {
+	return func(o *exportActivitiesOptions) {
+		o.resume = &cp
+	}
+}
+
+// WithExportActivitiesCheckpoint sets the function called after every page
+// is written with the position reached.
+func WithExportActivitiesCheckpoint(fn func(ExportActivitiesCheckpoint)) ExportActivitiesOption {
+	return func(o *exportActivitiesOptions) {
+		o.checkpoint = fn
+	}
+}
+
+// ExportActivities writes all the activities of the given feeds to w as JSON
+// lines of ExportedActivity, newest first, with their custom fields. Feeds are
+// read according to the type of their group registered with WithFeedGroups,
+// and as flat feeds if not registered. The activities of aggregated and
+// notification feeds are read from their groups, which only hold the latest
+// activities of the group. It returns the number of written activities,
+// including the ones counted by a resumed checkpoint.
+func (c *Client) ExportActivities(ctx context.Context, w io.Writer, feeds []FeedID, opts ...ExportActivitiesOption) (int, error) {
+	o := exportActivitiesOptions{pageSize: defaultExportActivitiesPage}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pageSize <= 0 {
+		o.pageSize = defaultExportActivitiesPage
+	}
+
+	cp := ExportActivitiesCheckpoint{}
+	if o.resume != nil {
+		cp = *o.resume
+		i := 0
+		for i < len(feeds) && feeds[i] != cp.Feed {
+			i++
+		}
+		if i == len(feeds) {
+			return cp.Count, fmt.Errorf("checkpoint feed %s is not exported", cp.Feed)
+		}
+		feeds = feeds[i:]
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for _, id := range feeds {
+		feed, err := newFeed(id.Slug, id.UserID, c)
+		if err != nil {
+			return cp.Count, err
+		}
+		if cp.Feed != id {
+			cp = ExportActivitiesCheckpoint{Feed: id, Count: cp.Count}
+		}
+		for {
+			page, err := c.activityPage(ctx, feed, o.pageSize, cp.After)
+			if err != nil {
+				return cp.Count, fmt.Errorf("cannot export activities of %s: %w", id, err)
+			}
+			for _, a := range page.activities {
+				if !o.copied && a.Origin != "" && a.Origin != id.String() {
+					continue
+				}
+				if err := enc.Encode(ExportedActivity{Feed: id, Activity: a}); err != nil {
+					return cp.Count, err
+				}
+				cp.Count++
+			}
+			if page.last != "" {
+				cp.After = page.last
+			}
+			if err := bw.Flush(); err != nil {
+				return cp.Count, err
+			}
+			if o.checkpoint != nil {
+				o.checkpoint(cp)
+			}
+			if !page.full {
+				break
+			}
+		}
+	}
+	return cp.Count, nil
+}
+
+// activitiesPage is a page of the activities of a feed.
+type activitiesPage struct {
+	activities []Activity
+	// last is the ID of the last activity, or group for aggregated and
+	// notification feeds, to read the next page from.
+	last string
+	// full tells whether the page has as many entries as requested, so that
+	// more may follow.
+	full bool
+}
+
+// feedType returns the type of the feed group registered with the given
+// slug, defaulting to flat feeds.
+func (c *Client) feedType(slug string) FeedType {
+	if g, ok := c.feedGroups[slug]; ok {
+		return g.Type
+	}
+	return FeedTypeFlat
+}
+
+// activityPage reads at most limit entries of the feed older than the entry
+// having the given ID, according to the type of the feed: the activities of
+// flat feeds, or the groups of aggregated and notification feeds, whose
+// activities are returned in order.
+func (c *Client) activityPage(ctx context.Context, feed Feed, limit int, after string) (activitiesPage, error) {
+	opts := []GetActivitiesOption{WithActivitiesLimit(limit)}
+	if after != "" {
+		opts = append(opts, WithActivitiesIDLT(after))
+	}
+	var (
+		page  activitiesPage
+		count int
+	)
+	switch c.feedType(feed.Slug()) {
+	case FeedTypeAggregated:
+		var resp AggregatedFeedResponse
+		if err := c.getActivities(ctx, feed, &resp, opts...); err != nil {
+			return page, err
+		}
+		for _, g := range resp.Results {
+			page.activities = append(page.activities, g.Activities...)
+			page.last = g.ID
+		}
+		count = len(resp.Results)
+	case FeedTypeNotification:
+		var resp NotificationFeedResponse
+		if err := c.getActivities(ctx, feed, &resp, opts...); err != nil {
+			return page, err
+		}
+		for _, g := range resp.Results {
+			page.activities = append(page.activities, g.Activities...)
+			page.last = g.ID
+		}
+		count = len(resp.Results)
+	default:
+		var resp FlatFeedResponse
+		if err := c.getActivities(ctx, feed, &resp, opts...); err != nil {
+			return page, err
+		}
+		page.activities = resp.Results
+		if len(resp.Results) > 0 {
+			page.last = resp.Results[len(resp.Results)-1].ID
+		}
+		count = len(resp.Results)
+	}
+	page.full = count >= limit
+	return page, nil
+}
+
+// ImportActivitiesOption configures ImportActivities.
+type ImportActivitiesOption func(*importActivitiesOptions)
+
+type importActivitiesOptions struct {
+	chunkSize int
+	offset    int
+	progress  func(offset int)
+}
+
+// WithImportActivitiesChunkSize sets how many activities are added per
+// AddActivities call, defaulting to and capped at 100.
+func WithImportActivitiesChunkSize(size int) ImportActivitiesOption {
+	return func(o *importActivitiesOptions) {
+		o.chunkSize = size
+	}
+}
+
+// WithImportActivitiesOffset skips the given number of activities, to resume
+// an interrupted import.
+func WithImportActivitiesOffset(offset int) ImportActivitiesOption {
+	return func(o *importActivitiesOptions) {
+		o.offset = offset
+	}
+}
+
+// WithImportActivitiesProgress sets the function called after every imported
+// chunk with the number of activities read so far, including the skipped
+// ones. It's the offset to resume the import from.
+func WithImportActivitiesProgress(fn func(offset int)) ImportActivitiesOption {
+	return func(o *importActivitiesOptions) {
+		o.progress = fn
+	}
+}
+
+// ImportActivities reads activities as written by ExportActivities from r and
+// adds them back to their feeds with AddActivities, in chunks and in order.
+// Activity IDs are dropped and assigned again by Stream, while ForeignID and
+// Time are kept so that importing an activity twice doesn't duplicate it.
+// Activities without a foreign ID get one derived from their original ID,
+// "import:" followed by the ID.
+// It returns the offset reached, which is the number of activities read
+// before any failing chunk, to be passed to WithImportActivitiesOffset to
+// resume the import.
+func (c *Client) ImportActivities(ctx context.Context, r io.Reader, opts ...ImportActivitiesOption) (int, error) {
+	o := importActivitiesOptions{chunkSize: maxAddActivities}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.chunkSize <= 0 || o.chunkSize > maxAddActivities {
+		o.chunkSize = maxAddActivities
+	}
+
+	dec := json.NewDecoder(r)
+	offset := 0
+	var chunkFeed FeedID
+	chunk := make([]Activity, 0, o.chunkSize)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		feed, err := newFeed(chunkFeed.Slug, chunkFeed.UserID, c)
+		if err != nil {
+			return err
+		}
+		if _, err := c.addActivities(ctx, feed, chunk...); err != nil {
+			return fmt.Errorf("cannot import activities to %s: %w", chunkFeed, err)
+		}
+		offset += len(chunk)
+		chunk = chunk[:0]
+		if o.progress != nil {
+			o.progress(offset)
+		}
+		return nil
+	}
+	for line := 0; ; line++ {
+		var exported ExportedActivity
+		if err := dec.Decode(&exported); err == io.EOF {
+			break
+		} else if err != nil {
+			return offset, fmt.Errorf("cannot decode activity %d: %w", line, err)
+		}
+		if line < o.offset {
+			offset++
+			continue
+		}
+		if err := exported.Feed.Validate(); err != nil {
+			return offset, fmt.Errorf("invalid feed of activity %d: %w", line, err)
+		}
+		if exported.Feed != chunkFeed || len(chunk) == o.chunkSize {
+			if err := flush(); err != nil {
+				return offset, err
+			}
+			chunkFeed = exported.Feed
+		}
+		a := exported.Activity
+		if a.ForeignID == "" && a.ID != "" {
+			a.ForeignID = importForeignIDPrefix + a.ID
+		}
+		a.ID = ""
+		a.Origin = ""
+		chunk = append(chunk, a)
+	}
+	return offset, flush()
+}
@@ -0,0 +1,237 @@
+package stream_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// activityStore is a fake feed backend storing activities newest first, and
+// the groups of aggregated feeds.
+type activityStore struct {
+	mu       sync.Mutex
+	feeds    map[string][]stream.Activity
+	groups   map[string][]stream.ActivityGroup
+	posts    int
+	failPost int
+}
+
+func (s *activityStore) Do(req *http.Request) (*http.Response, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	parts := strings.Split(strings.TrimSuffix(req.URL.Path, "/"), "/")
+	feed := parts[len(parts)-2] + ":" + parts[len(parts)-1]
+	switch req.Method {
+	case http.MethodGet:
+		limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+		idLT := req.URL.Query().Get("id_lt")
+		if groups, ok := s.groups[feed]; ok {
+			results := []stream.ActivityGroup{}
+			for _, g := range groups {
+				if (idLT == "" || g.ID < idLT) && len(results) < limit {
+					results = append(results, g)
+				}
+			}
+			b, _ := json.Marshal(map[string]any{"results": results})
+			return jsonResponse(http.StatusOK, string(b)), nil
+		}
+		results := []stream.Activity{}
+		for _, a := range s.feeds[feed] {
+			if (idLT == "" || a.ID < idLT) && len(results) < limit {
+				results = append(results, a)
+			}
+		}
+		b, _ := json.Marshal(map[string]any{"results": results})
+		return jsonResponse(http.StatusOK, string(b)), nil
+	case http.MethodPost:
+		s.posts++
+		if s.posts == s.failPost {
+			return jsonResponse(http.StatusBadRequest, `{"detail":"oops","status_code":400}`), nil
+		}
+		body, _ := io.ReadAll(req.Body)
+		var in struct {
+			Activities []stream.Activity `json:"activities"`
+		}
+		_ = json.Unmarshal(body, &in)
+	next:
+		for _, a := range in.Activities {
+			for _, stored := range s.feeds[feed] {
+				if a.ForeignID != "" && stored.ForeignID == a.ForeignID && stored.Time.Equal(a.Time.Time) {
+					continue next
+				}
+			}
+			a.ID = "new-" + a.ForeignID
+			s.feeds[feed] = append(s.feeds[feed], a)
+		}
+		b, _ := json.Marshal(map[string]any{"activities": in.Activities})
+		return jsonResponse(http.StatusCreated, string(b)), nil
+	}
+	return jsonResponse(http.StatusNotFound, `{}`), nil
+}
+
+func TestExportActivities(t *testing.T) {
+	store := &activityStore{feeds: map[string][]stream.Activity{}}
+	for i := 24; i >= 0; i-- {
+		store.feeds["user:1"] = append(store.feeds["user:1"], stream.Activity{
+			ID:        fmt.Sprintf("a%02d", i),
+			Actor:     "bob",
+			Verb:      "post",
+			Object:    "object",
+			ForeignID: fmt.Sprint("post:", i),
+			Extra:     map[string]any{"n": float64(i)},
+		})
+	}
+	store.feeds["user:2"] = []stream.Activity{
+		{ID: "b01", Actor: "bob", Verb: "post", Object: "object", ForeignID: "post:b1"},
+		{ID: "b00", Actor: "bob", Verb: "post", Object: "object", ForeignID: "post:b0", Origin: "user:3"},
+	}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(store))
+	require.NoError(t, err)
+	feeds := []stream.FeedID{stream.MustParseFeedID("user:1"), stream.MustParseFeedID("user:2")}
+
+	var buf bytes.Buffer
+	var checkpoints []stream.ExportActivitiesCheckpoint
+	n, err := client.ExportActivities(context.Background(), &buf, feeds,
+		stream.WithExportActivitiesPageSize(10),
+		stream.WithExportActivitiesCheckpoint(func(cp stream.ExportActivitiesCheckpoint) {
+			checkpoints = append(checkpoints, cp)
+		}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 26, n)
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	require.Len(t, lines, 26)
+	var first stream.ExportedActivity
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &first))
+	assert.Equal(t, "user:1", first.Feed.String())
+	assert.Equal(t, "post:24", first.Activity.ForeignID)
+	assert.Equal(t, float64(24), first.Activity.Extra["n"])
+	assert.Equal(t, []stream.ExportActivitiesCheckpoint{
+		{Feed: feeds[0], After: "a15", Count: 10},
+		{Feed: feeds[0], After: "a05", Count: 20},
+		{Feed: feeds[0], After: "a00", Count: 25},
+		{Feed: feeds[1], After: "b00", Count: 26},
+	}, checkpoints)
+
+	buf.Reset()
+	n, err = client.ExportActivities(context.Background(), &buf, feeds,
+		stream.WithExportActivitiesPageSize(10),
+		stream.WithExportActivitiesResume(checkpoints[1]),
+		stream.WithExportCopiedActivities(),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 27, n)
+	assert.Len(t, strings.Split(strings.TrimSpace(buf.String()), "\n"), 7)
+
+	_, err = client.ExportActivities(context.Background(), &buf, feeds[1:],
+		stream.WithExportActivitiesResume(checkpoints[0]))
+	assert.Error(t, err)
+}
+
+func TestExportActivitiesAggregatedFeed(t *testing.T) {
+	store := &activityStore{groups: map[string][]stream.ActivityGroup{}}
+	for i := 2; i >= 0; i-- {
+		var g stream.ActivityGroup
+		g.ID = fmt.Sprint("g", i)
+		g.Activities = []stream.Activity{
+			{ID: fmt.Sprint("a", i, "1"), Actor: "bob", Verb: "post", Object: "o"},
+			{ID: fmt.Sprint("a", i, "0"), Actor: "bob", Verb: "post", Object: "o"},
+		}
+		store.groups["aggregated:1"] = append(store.groups["aggregated:1"], g)
+	}
+	client, err := stream.New("key", "secret",
+		stream.WithHTTPRequester(store),
+		stream.WithFeedGroups(stream.FeedGroup{Slug: "aggregated", Type: stream.FeedTypeAggregated}),
+	)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	var checkpoints []stream.ExportActivitiesCheckpoint
+	n, err := client.ExportActivities(context.Background(), &buf, []stream.FeedID{stream.MustParseFeedID("aggregated:1")},
+		stream.WithExportActivitiesPageSize(2),
+		stream.WithExportActivitiesCheckpoint(func(cp stream.ExportActivitiesCheckpoint) {
+			checkpoints = append(checkpoints, cp)
+		}),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 6, n)
+	var ids []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var e stream.ExportedActivity
+		require.NoError(t, dec.Decode(&e))
+		ids = append(ids, e.Activity.ID)
+	}
+	assert.Equal(t, []string{"a21", "a20", "a11", "a10", "a01", "a00"}, ids)
+	require.Len(t, checkpoints, 2)
+	assert.Equal(t, "g1", checkpoints[0].After)
+	assert.Equal(t, "g0", checkpoints[1].After)
+}
+
+func TestImportActivities(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 15; i++ {
+		feed := "user:1"
+		if i >= 12 {
+			feed = "user:2"
+		}
+		fmt.Fprintf(&input, `{"feed":%q,"activity":{"id":"old","actor":"bob","verb":"post","object":"o","foreign_id":"post:%d","time":"2024-01-02T03:04:05.000000","origin":"user:9","n":%d}}`+"\n", feed, i, i)
+	}
+	store := &activityStore{feeds: map[string][]stream.Activity{}, failPost: 2}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(store))
+	require.NoError(t, err)
+
+	var progress []int
+	offset, err := client.ImportActivities(context.Background(), strings.NewReader(input.String()),
+		stream.WithImportActivitiesChunkSize(5),
+		stream.WithImportActivitiesProgress(func(offset int) { progress = append(progress, offset) }),
+	)
+	require.Error(t, err)
+	assert.Equal(t, 5, offset)
+	assert.Equal(t, []int{5}, progress)
+
+	progress = nil
+	offset, err = client.ImportActivities(context.Background(), strings.NewReader(input.String()),
+		stream.WithImportActivitiesChunkSize(5),
+		stream.WithImportActivitiesOffset(offset),
+		stream.WithImportActivitiesProgress(func(offset int) { progress = append(progress, offset) }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 15, offset)
+	assert.Equal(t, []int{10, 12, 15}, progress)
+	require.Len(t, store.feeds["user:1"], 12)
+	require.Len(t, store.feeds["user:2"], 3)
+	a := store.feeds["user:2"][0]
+	assert.Equal(t, "post:12", a.ForeignID)
+	assert.Equal(t, "new-post:12", a.ID)
+	assert.Empty(t, a.Origin)
+	assert.Equal(t, float64(12), a.Extra["n"])
+	assert.Equal(t, 2024, a.Time.Year())
+
+	_, err = client.ImportActivities(context.Background(), strings.NewReader(input.String()))
+	require.NoError(t, err)
+	assert.Len(t, store.feeds["user:1"], 12)
+
+	withoutForeignID := `{"feed":"user:3","activity":{"id":"x1","actor":"bob","verb":"post","object":"o","time":"2024-01-02T03:04:05.000000"}}`
+	for i := 0; i < 2; i++ {
+		_, err = client.ImportActivities(context.Background(), strings.NewReader(withoutForeignID))
+		require.NoError(t, err)
+	}
+	require.Len(t, store.feeds["user:3"], 1)
+	assert.Equal(t, "import:x1", store.feeds["user:3"][0].ForeignID)
+
+	_, err = client.ImportActivities(context.Background(), strings.NewReader(`{"feed":"bad feed","activity":{}}`))
+	assert.Error(t, err)
+}
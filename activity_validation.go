@@ -0,0 +1,159 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Activity limits enforced by Stream.
+const (
+	MaxActivityFieldLength = 255
+	MaxActivityToTargets   = 100
+	MaxActivitySize        = 10 * 1024
+)
+
+// reservedActivityFields are the field names which cannot be used as keys of
+// Activity.Extra, either because they map to Activity fields or because
+// they're reserved by Stream.
+var reservedActivityFields = map[string]bool{
+	"id":            true,
+	"actor":         true,
+	"verb":          true,
+	"object":        true,
+	"foreign_id":    true,
+	"target":        true,
+	"time":          true,
+	"origin":        true,
+	"to":            true,
+	"score":         true,
+	"score_vars":    true,
+	"activity_id":   true,
+	"activity":      true,
+	"analytics":     true,
+	"extra_context": true,
+	"is_read":       true,
+	"is_seen":       true,
+	"site_id":       true,
+}
+
+// ValidationError is a violation of the Stream constraints by a field of an
+// Activity.
+type ValidationError struct {
+	Field   string
+	Message string
+}
+
+func (e ValidationError) Error() string {
+	return e.Field + ": " + e.Message
+}
+
+// ValidationErrors lists the violations found when validating an Activity.
+type ValidationErrors []ValidationError
+
+func (e ValidationErrors) Error() string {
+	msgs := make([]string, len(e))
+	for i := range e {
+		msgs[i] = e[i].Error()
+	}
+	return "invalid activity: " + strings.Join(msgs, "; ")
+}
+
+// WithStrictValidation makes the Client validate activities with
+// Activity.Validate before adding or updating them, returning the validation
+// errors without performing any API call.
+func WithStrictValidation() ClientOption {
+	return func(c *Client) {
+		c.strictValidation = true
+	}
+}
+
+// Validate checks the activity against the constraints documented by Stream:
+// actor, verb and object are required, string fields are limited to
+// MaxActivityFieldLength characters, time must be UTC and is required along
+// with the foreign ID, to targets must be valid feed IDs and at most
+// MaxActivityToTargets, extra fields must not collide with reserved names, and
+// the encoded activity must not exceed MaxActivitySize bytes. It returns
+// ValidationErrors if any constraint is violated.
+func (a Activity) Validate() error {
+	var errs ValidationErrors
+	add := func(field, format string, args ...any) {
+		errs = append(errs, ValidationError{Field: field, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, f := range []struct {
+		name     string
+		value    string
+		required bool
+	}{
+		{"actor", a.Actor, true},
+		{"verb", a.Verb, true},
+		{"object", a.Object, true},
+		{"foreign_id", a.ForeignID, false},
+		{"target", a.Target, false},
+	} {
+		switch {
+		case f.value == "" && f.required:
+			add(f.name, "is required")
+		case len(f.value) > MaxActivityFieldLength:
+			add(f.name, "exceeds %d characters", MaxActivityFieldLength)
+		}
+	}
+
+	if !a.Time.IsZero() && a.Time.Location() != time.UTC {
+		add("time", "must be in UTC")
+	}
+	if a.ForeignID != "" && a.Time.IsZero() {
+		add("time", "is required when foreign_id is set")
+	}
+
+	if len(a.To) > MaxActivityToTargets {
+		add("to", "exceeds %d targets", MaxActivityToTargets)
+	}
+	for i, to := range a.To {
+		if _, err := ParseFeedID(to); err != nil {
+			add(fmt.Sprintf("to[%d]", i), "%q is not a valid feed ID", to)
+		}
+	}
+
+	keys := make([]string, 0, len(a.Extra))
+	for k := range a.Extra {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	for _, k := range keys {
+		if reservedActivityFields[k] {
+			add("extra."+k, "is a reserved field name")
+		}
+	}
+
+	if b, err := json.Marshal(a); err != nil {
+		add("extra", "cannot be encoded: %v", err)
+	} else if len(b) > MaxActivitySize {
+		add("extra", "activity size %d bytes exceeds %d bytes", len(b), MaxActivitySize)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// validateActivities validates the given activities if strict validation is
+// enabled.
+func (c *Client) validateActivities(activities ...Activity) error {
+	if !c.strictValidation {
+		return nil
+	}
+	for i, a := range activities {
+		if err := a.Validate(); err != nil {
+			if len(activities) == 1 {
+				return err
+			}
+			return fmt.Errorf("activity %d: %w", i, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,115 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestActivityValidate(t *testing.T) {
+	now := stream.Time{Time: time.Now().UTC()}
+	valid := stream.Activity{Actor: "bob", Verb: "like", Object: "cake", ForeignID: "like:1", Time: now, To: []string{"user:1"}}
+	require.NoError(t, valid.Validate())
+
+	testCases := []struct {
+		name     string
+		mutate   func(a *stream.Activity)
+		expected []string
+	}{
+		{
+			name:     "required",
+			mutate:   func(a *stream.Activity) { a.Actor, a.Verb, a.Object = "", "", "" },
+			expected: []string{"actor", "verb", "object"},
+		},
+		{
+			name:     "length",
+			mutate:   func(a *stream.Activity) { a.Target = strings.Repeat("x", 256) },
+			expected: []string{"target"},
+		},
+		{
+			name:     "non utc time",
+			mutate:   func(a *stream.Activity) { a.Time = stream.Time{Time: time.Now().In(time.FixedZone("CET", 3600))} },
+			expected: []string{"time"},
+		},
+		{
+			name:     "foreign id without time",
+			mutate:   func(a *stream.Activity) { a.Time = stream.Time{} },
+			expected: []string{"time"},
+		},
+		{
+			name: "to targets",
+			mutate: func(a *stream.Activity) {
+				a.To = []string{"user:1", "user"}
+				for i := 0; i < stream.MaxActivityToTargets; i++ {
+					a.To = append(a.To, "user:1")
+				}
+			},
+			expected: []string{"to", "to[1]"},
+		},
+		{
+			name:     "reserved extra",
+			mutate:   func(a *stream.Activity) { a.Extra = map[string]any{"verb": "x", "is_read": true, "custom": 1} },
+			expected: []string{"extra.is_read", "extra.verb"},
+		},
+		{
+			name: "size",
+			mutate: func(a *stream.Activity) {
+				a.Extra = map[string]any{"text": strings.Repeat("x", stream.MaxActivitySize)}
+			},
+			expected: []string{"extra"},
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			a := valid
+			tc.mutate(&a)
+			err := a.Validate()
+			var errs stream.ValidationErrors
+			require.True(t, errors.As(err, &errs))
+			fields := make([]string, len(errs))
+			for i := range errs {
+				fields[i] = errs[i].Field
+			}
+			assert.Equal(t, tc.expected, fields)
+		})
+	}
+}
+
+func TestStrictValidation(t *testing.T) {
+	requester := &mockRequester{}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithStrictValidation())
+	require.NoError(t, err)
+	ctx := context.Background()
+	feed, err := client.FlatFeed("user", "123")
+	require.NoError(t, err)
+	valid := stream.Activity{Actor: "bob", Verb: "like", Object: "cake"}
+	invalid := stream.Activity{Actor: "bob", Verb: "like"}
+
+	_, err = feed.AddActivity(ctx, invalid)
+	assert.EqualError(t, err, "invalid activity: object: is required")
+	_, err = feed.AddActivities(ctx, valid, invalid)
+	assert.EqualError(t, err, "activity 1: invalid activity: object: is required")
+	err = client.AddToMany(ctx, invalid, feed)
+	assert.Error(t, err)
+	_, err = client.UpdateActivities(ctx, invalid)
+	assert.Error(t, err)
+	assert.Nil(t, requester.req)
+
+	_, err = feed.AddActivity(ctx, valid)
+	require.NoError(t, err)
+	assert.NotNil(t, requester.req)
+
+	client, requester = newClient(t)
+	feed, err = client.FlatFeed("user", "123")
+	require.NoError(t, err)
+	_, err = feed.AddActivity(ctx, invalid)
+	require.NoError(t, err)
+	assert.NotNil(t, requester.req)
+}
@@ -2,7 +2,6 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // AggregatedFeed is a Stream aggregated feed, which contains activities grouped
@@ -14,12 +13,8 @@ type AggregatedFeed struct {
 // GetActivities requests and retrieves the activities and groups for the
 // aggregated feed.
 func (f *AggregatedFeed) GetActivities(ctx context.Context, opts ...GetActivitiesOption) (*AggregatedFeedResponse, error) {
-	body, err := f.client.getActivities(ctx, f, opts...)
-	if err != nil {
-		return nil, err
-	}
 	var resp AggregatedFeedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := f.client.getActivities(ctx, f, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -44,12 +39,8 @@ func (f *AggregatedFeed) GetNextPageActivities(ctx context.Context, resp *Aggreg
 // GetEnrichedActivities requests and retrieves the enriched activities and groups for the
 // aggregated feed.
 func (f *AggregatedFeed) GetEnrichedActivities(ctx context.Context, opts ...GetActivitiesOption) (*EnrichedAggregatedFeedResponse, error) {
-	body, err := f.client.getEnrichedActivities(ctx, f, opts...)
-	if err != nil {
-		return nil, err
-	}
 	var resp EnrichedAggregatedFeedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := f.client.getEnrichedActivities(ctx, f, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
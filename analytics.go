@@ -17,13 +17,21 @@ func (c *AnalyticsClient) TrackEngagement(ctx context.Context, events ...Engagem
 	data := map[string]any{
 		"content_list": events,
 	}
-	return decode(c.client.post(ctx, endpoint, data, c.client.authenticator.analyticsAuth))
+	var resp BaseResponse
+	if err := c.client.post(ctx, endpoint, data, &resp, c.client.authenticator.analyticsAuth); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 // TrackImpression is used to send and track analytics ImpressionEvents.
 func (c *AnalyticsClient) TrackImpression(ctx context.Context, eventsData ImpressionEventsData) (*BaseResponse, error) {
 	endpoint := c.client.makeEndpoint("impression/")
-	return decode(c.client.post(ctx, endpoint, eventsData, c.client.authenticator.analyticsAuth))
+	var resp BaseResponse
+	if err := c.client.post(ctx, endpoint, eventsData, &resp, c.client.authenticator.analyticsAuth); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 // RedirectAndTrack is used to send and track analytics ImpressionEvents. It tracks
@@ -31,6 +39,9 @@ func (c *AnalyticsClient) TrackImpression(ctx context.Context, eventsData Impres
 // URL string.
 func (c *AnalyticsClient) RedirectAndTrack(url string, events ...map[string]any) (string, error) {
 	endpoint := c.client.makeEndpoint("redirect/")
+	if endpoint.err != nil {
+		return "", endpoint.err
+	}
 	eventsData, err := json.Marshal(events)
 	if err != nil {
 		return "", err
@@ -1,11 +1,17 @@
 package stream
 
 import (
+	"bufio"
 	"context"
 	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
 	"time"
 )
 
+const defaultExportAuditLogsPage = 100
+
 type AuditLogsClient struct {
 	client *Client
 }
@@ -19,6 +25,19 @@ type AuditLog struct {
 	CreatedAt  time.Time      `json:"created_at"`
 }
 
+// DecodeCustom decodes the custom data of the log into v, which must be a
+// pointer, typically to a struct with JSON tags.
+func (l AuditLog) DecodeCustom(v any) error {
+	b, err := json.Marshal(l.Custom)
+	if err == nil {
+		err = json.Unmarshal(b, v)
+	}
+	if err != nil {
+		return fmt.Errorf("cannot decode custom data of %s audit log: %w", l.Action, err)
+	}
+	return nil
+}
+
 type QueryAuditLogsResponse struct {
 	AuditLogs []AuditLog `json:"audit_logs"`
 	Next      string     `json:"next"`
@@ -27,26 +46,57 @@ type QueryAuditLogsResponse struct {
 }
 
 type QueryAuditLogsFilters struct {
+	// EntityType restricts the logs to an entity type and, if EntityID is
+	// also set, to a single entity. EntityID is ignored without EntityType.
 	EntityType string
 	EntityID   string
 	UserID     string
+	// Actions restricts the logs to the given actions.
+	Actions []string
+	// CreatedAfter and CreatedBefore bound the creation time of the logs,
+	// inclusively. Zero values are unbounded.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
 }
 
+// AuditLogsOrder is the order in which audit logs are returned.
+type AuditLogsOrder string
+
+const (
+	// AuditLogsNewestFirst returns the most recent logs first. It's the
+	// default order.
+	AuditLogsNewestFirst AuditLogsOrder = "desc"
+	// AuditLogsOldestFirst returns the oldest logs first.
+	AuditLogsOldestFirst AuditLogsOrder = "asc"
+)
+
 type QueryAuditLogsPager struct {
 	Next  string
 	Prev  string
 	Limit int
+	Order AuditLogsOrder
 }
 
 func (c *AuditLogsClient) QueryAuditLogs(ctx context.Context, filters QueryAuditLogsFilters, pager QueryAuditLogsPager) (*QueryAuditLogsResponse, error) {
 	endpoint := c.client.makeEndpoint("audit_logs/")
-	if filters.EntityType != "" && filters.EntityID != "" {
+	if filters.EntityType != "" {
 		endpoint.addQueryParam(makeRequestOption("entity_type", filters.EntityType))
-		endpoint.addQueryParam(makeRequestOption("entity_id", filters.EntityID))
+		if filters.EntityID != "" {
+			endpoint.addQueryParam(makeRequestOption("entity_id", filters.EntityID))
+		}
 	}
 	if filters.UserID != "" {
 		endpoint.addQueryParam(makeRequestOption("user_id", filters.UserID))
 	}
+	if len(filters.Actions) > 0 {
+		endpoint.addQueryParam(makeRequestOption("action", strings.Join(filters.Actions, ",")))
+	}
+	if !filters.CreatedAfter.IsZero() {
+		endpoint.addQueryParam(makeRequestOption("created_at_gte", filters.CreatedAfter.UTC().Format(time.RFC3339)))
+	}
+	if !filters.CreatedBefore.IsZero() {
+		endpoint.addQueryParam(makeRequestOption("created_at_lte", filters.CreatedBefore.UTC().Format(time.RFC3339)))
+	}
 	if pager.Next != "" {
 		endpoint.addQueryParam(makeRequestOption("next", pager.Next))
 	}
@@ -56,15 +106,123 @@ func (c *AuditLogsClient) QueryAuditLogs(ctx context.Context, filters QueryAudit
 	if pager.Limit > 0 {
 		endpoint.addQueryParam(makeRequestOption("limit", pager.Limit))
 	}
-	body, err := c.client.get(ctx, endpoint, nil, c.client.authenticator.auditLogsAuth)
-	if err != nil {
-		return nil, err
+	switch pager.Order {
+	case "":
+	case AuditLogsNewestFirst, AuditLogsOldestFirst:
+		endpoint.addQueryParam(makeRequestOption("order", string(pager.Order)))
+	default:
+		return nil, fmt.Errorf("invalid audit logs order %q", pager.Order)
 	}
-
 	var resp QueryAuditLogsResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := c.client.get(ctx, endpoint, nil, &resp, c.client.authenticator.auditLogsAuth); err != nil {
 		return nil, err
 	}
 
 	return &resp, nil
 }
+
+// ExportAuditLogsCheckpoint is the position reached by ExportAuditLogs. It's
+// meant to be persisted and passed to WithExportAuditLogsResume to resume an
+// interrupted export.
+type ExportAuditLogsCheckpoint struct {
+	// Next is the cursor of the next page to export.
+	Next string `json:"next,omitempty"`
+	// Count is the number of exported logs.
+	Count int `json:"count"`
+	// LastCreatedAt is the creation time of the last exported log. When
+	// exporting oldest first, it can be used as the CreatedAfter filter of
+	// the next export; being inclusive, the logs created at that time are
+	// exported again.
+	LastCreatedAt time.Time `json:"last_created_at,omitempty"`
+	// Done tells whether the export completed.
+	Done bool `json:"done,omitempty"`
+}
+
+// ExportAuditLogsOption configures ExportAuditLogs.
+type ExportAuditLogsOption func(*exportAuditLogsOptions)
+
+type exportAuditLogsOptions struct {
+	pageSize   int
+	order      AuditLogsOrder
+	resume     *ExportAuditLogsCheckpoint
+	checkpoint func(ExportAuditLogsCheckpoint)
+}
+
+// WithExportAuditLogsPageSize sets how many logs are read per request,
+// defaulting to 100.
+func WithExportAuditLogsPageSize(size int) ExportAuditLogsOption {
+	return func(o *exportAuditLogsOptions) {
+		o.pageSize = size
+	}
+}
+
+// WithExportAuditLogsOrder sets the order in which the logs are exported.
+func WithExportAuditLogsOrder(order AuditLogsOrder) ExportAuditLogsOption {
+	return func(o *exportAuditLogsOptions) {
+		o.order = order
+	}
+}
+
+// WithExportAuditLogsResume resumes an export from the given checkpoint. The
+// filters and order must be the ones of the interrupted export.
+func WithExportAuditLogsResume(cp ExportAuditLogsCheckpoint) ExportAuditLogsOption {
+	return func(o *exportAuditLogsOptions) {
+		o.resume = &cp
+	}
+}
+
+// WithExportAuditLogsCheckpoint sets the function called after every page is
+// written with the position reached.
+func WithExportAuditLogsCheckpoint(fn func(ExportAuditLogsCheckpoint)) ExportAuditLogsOption {
+	return func(o *exportAuditLogsOptions) {
+		o.checkpoint = fn
+	}
+}
+
+// ExportAuditLogs writes all the audit logs matching the filters to w as JSON
+// lines of AuditLog, following the Next cursors. It returns the number of
+// written logs, including the ones counted by a resumed checkpoint.
+func (c *AuditLogsClient) ExportAuditLogs(ctx context.Context, w io.Writer, filters QueryAuditLogsFilters, opts ...ExportAuditLogsOption) (int, error) {
+	o := exportAuditLogsOptions{pageSize: defaultExportAuditLogsPage}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pageSize <= 0 {
+		o.pageSize = defaultExportAuditLogsPage
+	}
+
+	cp := ExportAuditLogsCheckpoint{}
+	if o.resume != nil {
+		cp = *o.resume
+		if cp.Done {
+			return cp.Count, nil
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	for {
+		resp, err := c.QueryAuditLogs(ctx, filters, QueryAuditLogsPager{Next: cp.Next, Limit: o.pageSize, Order: o.order})
+		if err != nil {
+			return cp.Count, fmt.Errorf("cannot export audit logs: %w", err)
+		}
+		for _, l := range resp.AuditLogs {
+			if err := enc.Encode(l); err != nil {
+				return cp.Count, err
+			}
+			cp.Count++
+			cp.LastCreatedAt = l.CreatedAt
+		}
+		if err := bw.Flush(); err != nil {
+			return cp.Count, err
+		}
+		cp.Next = resp.Next
+		cp.Done = resp.Next == ""
+		if o.checkpoint != nil {
+			o.checkpoint(cp)
+		}
+		if cp.Done {
+			return cp.Count, nil
+		}
+	}
+}
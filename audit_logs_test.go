@@ -1,8 +1,10 @@
 package stream_test
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"io"
 	"net/http"
 	"testing"
 	"time"
@@ -118,3 +120,123 @@ func TestQueryAuditLogsWithMinimalParams(t *testing.T) {
 	assert.Empty(t, resp.Next)
 	assert.Empty(t, resp.Prev)
 }
+
+func TestQueryAuditLogsFilters(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	requester.resp = `{"audit_logs":[]}`
+
+	filters := stream.QueryAuditLogsFilters{
+		EntityType:    "activity",
+		Actions:       []string{"create", "delete"},
+		CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC),
+	}
+	_, err := client.AuditLogs().QueryAuditLogs(ctx, filters, stream.QueryAuditLogsPager{Order: stream.AuditLogsOldestFirst})
+	require.NoError(t, err)
+	testRequest(
+		t,
+		requester.req,
+		http.MethodGet,
+		"https://api.stream-io-api.com/api/v1.0/audit_logs/?action=create%2Cdelete&api_key=key&created_at_gte=2024-01-01T00%3A00%3A00Z&created_at_lte=2024-01-31T23%3A59%3A59Z&entity_type=activity&order=asc",
+		"",
+	)
+
+	_, err = client.AuditLogs().QueryAuditLogs(ctx, stream.QueryAuditLogsFilters{EntityID: "123"}, stream.QueryAuditLogsPager{})
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://api.stream-io-api.com/api/v1.0/audit_logs/?api_key=key", "")
+
+	requester.req = nil
+	_, err = client.AuditLogs().QueryAuditLogs(ctx, stream.QueryAuditLogsFilters{}, stream.QueryAuditLogsPager{Order: "random"})
+	assert.EqualError(t, err, `invalid audit logs order "random"`)
+	assert.Nil(t, requester.req)
+}
+
+func TestAuditLogDecodeCustom(t *testing.T) {
+	log := stream.AuditLog{Action: "ban", Custom: map[string]any{"reason": "spam", "minutes": 60.0}}
+	var custom struct {
+		Reason  string `json:"reason"`
+		Minutes int    `json:"minutes"`
+	}
+	require.NoError(t, log.DecodeCustom(&custom))
+	assert.Equal(t, "spam", custom.Reason)
+	assert.Equal(t, 60, custom.Minutes)
+
+	var wrong struct {
+		Reason int `json:"reason"`
+	}
+	assert.ErrorContains(t, log.DecodeCustom(&wrong), "cannot decode custom data of ban audit log")
+}
+
+func TestExportAuditLogs(t *testing.T) {
+	ctx := context.Background()
+	pages := map[string]string{
+		"":   `{"audit_logs":[{"action":"create","entity_id":"1","created_at":"2024-01-01T00:00:00Z"},{"action":"update","entity_id":"1","created_at":"2024-01-02T00:00:00Z"}],"next":"p2"}`,
+		"p2": `{"audit_logs":[{"action":"delete","entity_id":"1","created_at":"2024-01-03T00:00:00Z"}],"next":"p3"}`,
+		"p3": `{"audit_logs":[],"next":""}`,
+	}
+	failAt := ""
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		assert.Equal(t, "10", r.query.Get("limit"))
+		assert.Equal(t, "asc", r.query.Get("order"))
+		assert.Equal(t, "activity", r.query.Get("entity_type"))
+		if r.query.Get("next") == failAt {
+			return errorResponse(http.StatusInternalServerError, "boom"), nil
+		}
+		return jsonResponse(http.StatusOK, pages[r.query.Get("next")]), nil
+	})
+	queries := func() []string {
+		var queries []string
+		for _, r := range requester.recorded() {
+			queries = append(queries, r.query.Get("next"))
+		}
+		return queries
+	}
+	filters := stream.QueryAuditLogsFilters{EntityType: "activity"}
+	opts := []stream.ExportAuditLogsOption{
+		stream.WithExportAuditLogsPageSize(10),
+		stream.WithExportAuditLogsOrder(stream.AuditLogsOldestFirst),
+	}
+
+	failAt = "p2"
+	var checkpoints []stream.ExportAuditLogsCheckpoint
+	var buf bytes.Buffer
+	n, err := client.AuditLogs().ExportAuditLogs(ctx, &buf, filters, append(opts, stream.WithExportAuditLogsCheckpoint(func(cp stream.ExportAuditLogsCheckpoint) {
+		checkpoints = append(checkpoints, cp)
+	}))...)
+	require.Error(t, err)
+	assert.Equal(t, 2, n)
+	require.Len(t, checkpoints, 1)
+	assert.Equal(t, stream.ExportAuditLogsCheckpoint{
+		Next:          "p2",
+		Count:         2,
+		LastCreatedAt: time.Date(2024, 1, 2, 0, 0, 0, 0, time.UTC),
+	}, checkpoints[0])
+
+	failAt = "never"
+	requester.reset()
+	n, err = client.AuditLogs().ExportAuditLogs(ctx, &buf, filters, append(opts, stream.WithExportAuditLogsResume(checkpoints[0]), stream.WithExportAuditLogsCheckpoint(func(cp stream.ExportAuditLogsCheckpoint) {
+		checkpoints = append(checkpoints, cp)
+	}))...)
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Equal(t, []string{"p2", "p3"}, queries())
+	last := checkpoints[len(checkpoints)-1]
+	assert.True(t, last.Done)
+	assert.Equal(t, 3, last.Count)
+
+	var actions []string
+	dec := json.NewDecoder(&buf)
+	for dec.More() {
+		var l stream.AuditLog
+		require.NoError(t, dec.Decode(&l))
+		actions = append(actions, l.Action)
+	}
+	assert.Equal(t, []string{"create", "update", "delete"}, actions)
+
+	requester.reset()
+	n, err = client.AuditLogs().ExportAuditLogs(ctx, io.Discard, filters, stream.WithExportAuditLogsResume(last))
+	require.NoError(t, err)
+	assert.Equal(t, 3, n)
+	assert.Empty(t, queries())
+}
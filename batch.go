@@ -0,0 +1,284 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	defaultBatchConcurrency = 4
+	maxRateLimitRetries     = 3
+	maxRateLimitDelay       = time.Minute
+)
+
+// ErrBatchSkipped is the error of the items of a batch operation which were
+// not processed because of a previous failure, when WithBatchStopOnError is
+// used.
+var ErrBatchSkipped = errors.New("skipped after a previous error")
+
+// ErrBatchUnknown is the error of the items of a batch operation whose API
+// call was canceled while in flight because of a previous failure, when
+// WithBatchStopOnError is used: they may have been processed.
+var ErrBatchUnknown = errors.New("canceled after a previous error, may have been processed")
+
+// BatchError is returned by the operations splitting their items in several
+// API calls without returning a BatchResult, such as AddToMany, when some of
+// the calls fail. It wraps the first error.
+type BatchError struct {
+	Result *BatchResult
+}
+
+func (e *BatchError) Error() string {
+	return fmt.Sprintf("%v (%d of %d items failed)", e.Result.Err(), len(e.Result.Failed()), len(e.Result.Items))
+}
+
+func (e *BatchError) Unwrap() error {
+	return e.Result.Err()
+}
+
+// batchError returns a BatchError for the result if any of its items failed,
+// or the error of ctx if it ended before any item was processed.
+func batchError(ctx context.Context, result *BatchResult) error {
+	if result.Err() == nil {
+		return nil
+	}
+	if err := ctx.Err(); err != nil {
+		for _, item := range result.Items {
+			if !errors.Is(item.Err, err) {
+				return &BatchError{Result: result}
+			}
+		}
+		return err
+	}
+	return &BatchError{Result: result}
+}
+
+// BatchItemResult is the outcome of a single item of a batch operation.
+type BatchItemResult struct {
+	// Index is the position of the item in the input.
+	Index int
+	// ID identifies the item, such as the activity ID or foreign ID.
+	ID string
+	// Err is the error of the API call processing the item, if any.
+	Err error
+}
+
+// BatchResult is the outcome of a batch operation, with a result per item in
+// input order. The batch operations return it along with the error returned
+// by its Err method.
+type BatchResult struct {
+	Items []BatchItemResult
+}
+
+// Succeeded returns the results of the items processed successfully.
+func (r *BatchResult) Succeeded() []BatchItemResult {
+	return r.filter(func(i BatchItemResult) bool { return i.Err == nil })
+}
+
+// Failed returns the results of the items which failed, were skipped or have
+// an unknown outcome.
+func (r *BatchResult) Failed() []BatchItemResult {
+	return r.filter(func(i BatchItemResult) bool { return i.Err != nil })
+}
+
+// Err returns the first error, in input order, which isn't ErrBatchSkipped
+// or ErrBatchUnknown.
+func (r *BatchResult) Err() error {
+	for _, i := range r.Items {
+		if i.Err != nil && !errors.Is(i.Err, ErrBatchSkipped) && !errors.Is(i.Err, ErrBatchUnknown) {
+			return i.Err
+		}
+	}
+	return nil
+}
+
+func (r *BatchResult) filter(fn func(BatchItemResult) bool) []BatchItemResult {
+	var items []BatchItemResult
+	for _, i := range r.Items {
+		if fn(i) {
+			items = append(items, i)
+		}
+	}
+	return items
+}
+
+// BatchOption configures a batch operation.
+type BatchOption func(*batchOptions)
+
+type batchOptions struct {
+	size        int
+	concurrency int
+	stopOnError bool
+}
+
+// WithBatchSize sets the number of items sent in a single API call. It's
+// capped to the limit of the API, which is also the default.
+func WithBatchSize(size int) BatchOption {
+	return func(o *batchOptions) {
+		o.size = size
+	}
+}
+
+// WithBatchConcurrency sets the number of API calls performed concurrently,
+// defaulting to 4.
+func WithBatchConcurrency(n int) BatchOption {
+	return func(o *batchOptions) {
+		o.concurrency = n
+	}
+}
+
+// WithBatchStopOnError stops the batch operation at the first failing API
+// call. The items which are not processed yet fail with ErrBatchSkipped, and
+// the ones whose call was in flight with ErrBatchUnknown.
+func WithBatchStopOnError() BatchOption {
+	return func(o *batchOptions) {
+		o.stopOnError = true
+	}
+}
+
+// runBatch splits the n items in chunks of at most maxSize items and calls fn
+// for each of them concurrently, returning the per-item results. ids returns
+// the ID of the i-th item. When rate limited, all the workers pause until the
+// limit resets and the chunk is retried.
+func runBatch(ctx context.Context, n, maxSize int, ids func(i int) string, opts []BatchOption, fn func(ctx context.Context, start, end int) error) *BatchResult {
+	o := batchOptions{size: maxSize, concurrency: defaultBatchConcurrency}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.size <= 0 || o.size > maxSize {
+		o.size = maxSize
+	}
+	if o.concurrency <= 0 {
+		o.concurrency = 1
+	}
+
+	result := &BatchResult{Items: make([]BatchItemResult, n)}
+	for i := range result.Items {
+		result.Items[i] = BatchItemResult{Index: i, ID: ids(i), Err: ErrBatchSkipped}
+	}
+
+	parent := ctx
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	chunks := make(chan int)
+	var (
+		wg      sync.WaitGroup
+		stopped bool
+		mu      sync.Mutex
+		limiter rateLimitPause
+	)
+	for w := 0; w < o.concurrency; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for start := range chunks {
+				if ctx.Err() != nil {
+					continue
+				}
+				end := min(start+o.size, n)
+				var (
+					err  error
+					sent bool
+				)
+				for attempt := 0; ; attempt++ {
+					if err = limiter.wait(ctx); err != nil {
+						break
+					}
+					sent = true
+					err = fn(ctx, start, end)
+					if attempt >= maxRateLimitRetries || !limiter.limited(err) {
+						break
+					}
+				}
+				mu.Lock()
+				if o.stopOnError && stopped {
+					// a concurrent chunk failed first, and canceled this one
+					switch {
+					case err == nil:
+					case sent:
+						err = ErrBatchUnknown
+					default:
+						err = ErrBatchSkipped
+					}
+				} else if err != nil && o.stopOnError {
+					stopped = true
+					cancel()
+				}
+				for i := start; i < end; i++ {
+					result.Items[i].Err = err
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+send:
+	for start := 0; start < n; start += o.size {
+		select {
+		case chunks <- start:
+		case <-ctx.Done():
+			break send
+		}
+	}
+	close(chunks)
+	wg.Wait()
+
+	if err := parent.Err(); err != nil {
+		for i := range result.Items {
+			if errors.Is(result.Items[i].Err, ErrBatchSkipped) {
+				result.Items[i].Err = err
+			}
+		}
+	}
+	return result
+}
+
+// rateLimitPause pauses the workers of a batch operation after an API call is
+// rate limited.
+type rateLimitPause struct {
+	mu    sync.Mutex
+	until time.Time
+}
+
+// limited tells whether err is a rate limiting error, pausing the workers
+// until the limit resets if so.
+func (p *rateLimitPause) limited(err error) bool {
+	apiErr, ok := ToAPIError(err)
+	if !ok || apiErr.StatusCode != http.StatusTooManyRequests {
+		return false
+	}
+	now := time.Now()
+	until := now.Add(time.Second)
+	if apiErr.Rate != nil && apiErr.Rate.Reset.After(now) {
+		until = apiErr.Rate.Reset.Time
+	}
+	if until.Sub(now) > maxRateLimitDelay {
+		until = now.Add(maxRateLimitDelay)
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if until.After(p.until) {
+		p.until = until
+	}
+	return true
+}
+
+func (p *rateLimitPause) wait(ctx context.Context) error {
+	p.mu.Lock()
+	d := time.Until(p.until)
+	p.mu.Unlock()
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,145 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+)
+
+// Maximum number of items per API call of the bulk operations.
+const (
+	maxBatchUpdateActivities        = 100
+	maxBatchPartialUpdateActivities = 100
+	maxAddToManyFeeds               = 100
+	maxFollowManyRelationships      = 2500
+	maxUnfollowManyRelationships    = 2500
+)
+
+// RemoveActivitiesByID removes the activities having the given IDs from the
+// feed, removing several activities concurrently. The BatchResult reports the
+// outcome of every activity, identified by its ID.
+func (c *Client) RemoveActivitiesByID(ctx context.Context, feed Feed, ids []string, opts ...BatchOption) (*BatchResult, error) {
+	result := runBatch(ctx, len(ids), 1, func(i int) string { return ids[i] }, opts, func(ctx context.Context, start, _ int) error {
+		_, err := c.removeActivityByID(ctx, feed, ids[start])
+		return err
+	})
+	return result, result.Err()
+}
+
+// RemoveActivitiesByForeignID removes the activities having the given
+// foreign IDs from the feed, removing several activities concurrently. The
+// BatchResult reports the outcome of every activity, identified by its
+// foreign ID.
+func (c *Client) RemoveActivitiesByForeignID(ctx context.Context, feed Feed, foreignIDs []string, opts ...BatchOption) (*BatchResult, error) {
+	result := runBatch(ctx, len(foreignIDs), 1, func(i int) string { return foreignIDs[i] }, opts, func(ctx context.Context, start, _ int) error {
+		_, err := c.removeActivityByForeignID(ctx, feed, foreignIDs[start])
+		return err
+	})
+	return result, result.Err()
+}
+
+// BulkUpdateActivities fully updates any number of activities, splitting them
+// in chunks of at most 100 activities updated concurrently. The BatchResult
+// reports the outcome of every activity, identified by its ID or, lacking
+// one, by its foreign ID.
+func (c *Client) BulkUpdateActivities(ctx context.Context, activities []Activity, opts ...BatchOption) (*BatchResult, error) {
+	id := func(i int) string {
+		if activities[i].ID != "" {
+			return activities[i].ID
+		}
+		return activities[i].ForeignID
+	}
+	result := runBatch(ctx, len(activities), maxBatchUpdateActivities, id, opts, func(ctx context.Context, start, end int) error {
+		_, err := c.UpdateActivities(ctx, activities[start:end]...)
+		return err
+	})
+	return result, result.Err()
+}
+
+// BulkPartialUpdateActivities partially updates any number of activities,
+// splitting the changesets in chunks of at most 100 changesets applied
+// concurrently. The BatchResult reports the outcome of every changeset.
+func (c *Client) BulkPartialUpdateActivities(ctx context.Context, changesets []UpdateActivityRequest, opts ...BatchOption) (*BatchResult, error) {
+	id := func(i int) string {
+		switch {
+		case changesets[i].ID != nil:
+			return *changesets[i].ID
+		case changesets[i].ForeignID != nil:
+			return *changesets[i].ForeignID
+		}
+		return ""
+	}
+	result := runBatch(ctx, len(changesets), maxBatchPartialUpdateActivities, id, opts, func(ctx context.Context, start, end int) error {
+		_, err := c.PartialUpdateActivities(ctx, changesets[start:end]...)
+		return err
+	})
+	return result, result.Err()
+}
+
+// AddToManyBatched adds the activity to any number of feeds, splitting them
+// in chunks of at most 100 feeds added concurrently. Above 100 feeds, the
+// activity must have a foreign ID and a time, so that the same activity is
+// added to all the feeds. The BatchResult reports the outcome of every feed,
+// identified by its ID.
+func (c *Client) AddToManyBatched(ctx context.Context, activity Activity, feeds []Feed, opts ...BatchOption) (*BatchResult, error) {
+	if err := c.validateActivities(activity); err != nil {
+		return nil, err
+	}
+	flagged, err := c.screenActivities(ctx, activity)
+	if err != nil {
+		return nil, err
+	}
+	result, err := c.addToManyBatched(ctx, activity, feeds, opts)
+	if result != nil && len(result.Succeeded()) > 0 {
+		c.flagActivities(ctx, []Activity{activity}, flagged)
+	}
+	return result, err
+}
+
+func (c *Client) addToManyBatched(ctx context.Context, activity Activity, feeds []Feed, opts []BatchOption) (*BatchResult, error) {
+	if len(feeds) > maxAddToManyFeeds && (activity.ForeignID == "" || activity.Time.IsZero()) {
+		return nil, fmt.Errorf("adding an activity to more than %d feeds requires its foreign ID and time", maxAddToManyFeeds)
+	}
+	result := runBatch(ctx, len(feeds), maxAddToManyFeeds, func(i int) string { return feeds[i].ID() }, opts, func(ctx context.Context, start, end int) error {
+		return c.addToMany(ctx, activity, feeds[start:end])
+	})
+	return result, result.Err()
+}
+
+// FollowManyBatched creates any number of follows, splitting them in chunks
+// of at most 2500 relationships created concurrently. The activity copy limit
+// can be set per relationship with WithFollowRelationshipActivityCopyLimit.
+// The BatchResult reports the outcome of every relationship, identified as
+// "source->target".
+func (c *Client) FollowManyBatched(ctx context.Context, relationships []FollowRelationship, opts ...BatchOption) (*BatchResult, error) {
+	if c.feedGroups != nil {
+		for _, r := range relationships {
+			if err := c.checkFollow(r.Source, r.Target); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return c.followManyBatched(ctx, relationships, nil, opts)
+}
+
+func (c *Client) followManyBatched(ctx context.Context, relationships []FollowRelationship, followOpts []FollowManyOption, opts []BatchOption) (*BatchResult, error) {
+	id := func(i int) string {
+		return relationships[i].Source + "->" + relationships[i].Target
+	}
+	result := runBatch(ctx, len(relationships), maxFollowManyRelationships, id, opts, func(ctx context.Context, start, end int) error {
+		return c.followMany(ctx, relationships[start:end], followOpts...)
+	})
+	return result, result.Err()
+}
+
+// UnfollowManyBatched removes any number of follows, splitting them in chunks
+// of at most 2500 relationships removed concurrently. The BatchResult reports
+// the outcome of every relationship, identified as "source->target".
+func (c *Client) UnfollowManyBatched(ctx context.Context, relationships []UnfollowRelationship, opts ...BatchOption) (*BatchResult, error) {
+	id := func(i int) string {
+		return relationships[i].Source + "->" + relationships[i].Target
+	}
+	result := runBatch(ctx, len(relationships), maxUnfollowManyRelationships, id, opts, func(ctx context.Context, start, end int) error {
+		return c.unfollowMany(ctx, relationships[start:end])
+	})
+	return result, result.Err()
+}
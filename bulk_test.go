@@ -0,0 +1,245 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestRemoveActivitiesByID(t *testing.T) {
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		if strings.HasSuffix(r.path, "/a3/") {
+			return errorResponse(http.StatusNotFound, "not found"), nil
+		}
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})
+	feed, err := client.FlatFeed("user", "123")
+	require.NoError(t, err)
+
+	result, err := client.RemoveActivitiesByID(context.Background(), feed, []string{"a1", "a2", "a3", "a4"}, stream.WithBatchConcurrency(2))
+	require.Error(t, err)
+	assert.Len(t, requester.recorded(), 4)
+	require.Len(t, result.Failed(), 1)
+	assert.Equal(t, "a3", result.Failed()[0].ID)
+	assert.Equal(t, 2, result.Failed()[0].Index)
+	assert.Len(t, result.Succeeded(), 3)
+	assert.Equal(t, err, result.Err())
+
+	requester.reset()
+	result, err = client.RemoveActivitiesByForeignID(context.Background(), feed, []string{"f1", "f2"})
+	require.NoError(t, err)
+	assert.Len(t, result.Succeeded(), 2)
+	assert.ElementsMatch(t, []string{"DELETE /api/v1.0/feed/user/123/f1/", "DELETE /api/v1.0/feed/user/123/f2/"}, requester.paths())
+}
+
+func TestBulkPartialUpdateActivities(t *testing.T) {
+	type changes struct {
+		Changes []stream.UpdateActivityRequest `json:"changes"`
+	}
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		var payload changes
+		if err := r.decode(&payload); err != nil {
+			return nil, err
+		}
+		if *payload.Changes[0].ID == "100" {
+			return errorResponse(http.StatusBadRequest, "bad"), nil
+		}
+		return jsonResponse(http.StatusCreated, `{"activities":[]}`), nil
+	})
+	chunks := func() []int {
+		var sizes []int
+		for _, r := range requester.recorded() {
+			var payload changes
+			require.NoError(t, r.decode(&payload))
+			sizes = append(sizes, len(payload.Changes))
+		}
+		return sizes
+	}
+
+	changesets := make([]stream.UpdateActivityRequest, 250)
+	for i := range changesets {
+		changesets[i] = stream.NewUpdateActivityRequestByID(fmt.Sprint(i), map[string]any{"n": i}, nil)
+	}
+
+	result, err := client.BulkPartialUpdateActivities(context.Background(), changesets)
+	require.Error(t, err)
+	assert.ElementsMatch(t, []int{100, 100, 50}, chunks())
+	failed := result.Failed()
+	require.Len(t, failed, 100)
+	assert.Equal(t, "100", failed[0].ID)
+	assert.Equal(t, "199", failed[99].ID)
+
+	requester.reset()
+	result, err = client.BulkPartialUpdateActivities(context.Background(), changesets,
+		stream.WithBatchSize(50), stream.WithBatchConcurrency(1), stream.WithBatchStopOnError())
+	require.Error(t, err)
+	assert.Equal(t, []int{50, 50, 50}, chunks())
+	assert.Len(t, result.Succeeded(), 100)
+	failed = result.Failed()
+	require.Len(t, failed, 150)
+	assert.False(t, errors.Is(failed[0].Err, stream.ErrBatchSkipped))
+	assert.True(t, errors.Is(failed[50].Err, stream.ErrBatchSkipped))
+}
+
+func TestBulkUpdateActivities(t *testing.T) {
+	client, requester := newRecordingClient(t, nil)
+
+	activities := make([]stream.Activity, 101)
+	for i := range activities {
+		activities[i] = stream.Activity{ForeignID: fmt.Sprint("f", i), Actor: "bob", Verb: "like", Object: "cake"}
+	}
+	result, err := client.BulkUpdateActivities(context.Background(), activities, stream.WithBatchConcurrency(1))
+	require.NoError(t, err)
+	assert.Len(t, requester.recorded(), 2)
+	assert.Equal(t, "f100", result.Items[100].ID)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	result, err = client.BulkUpdateActivities(ctx, activities)
+	assert.True(t, errors.Is(err, context.Canceled))
+	assert.Len(t, result.Failed(), 101)
+}
+
+func TestAddToManyChunking(t *testing.T) {
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		var req stream.AddToManyRequest
+		if err := r.decode(&req); err != nil {
+			return nil, err
+		}
+		if req.FeedIDs[0] == "user:100" {
+			return errorResponse(http.StatusInternalServerError, "oops"), nil
+		}
+		return jsonResponse(http.StatusCreated, `{}`), nil
+	})
+	requests := func() []stream.AddToManyRequest {
+		var requests []stream.AddToManyRequest
+		for _, r := range requester.recorded() {
+			var req stream.AddToManyRequest
+			require.NoError(t, r.decode(&req))
+			requests = append(requests, req)
+		}
+		return requests
+	}
+
+	feeds := make([]stream.Feed, 250)
+	for i := range feeds {
+		var err error
+		feeds[i], err = client.FlatFeed("user", fmt.Sprint(i))
+		require.NoError(t, err)
+	}
+	activity := stream.Activity{Actor: "bob", Verb: "like", Object: "cake"}
+
+	_, err := client.AddToManyBatched(context.Background(), activity, feeds)
+	assert.EqualError(t, err, "adding an activity to more than 100 feeds requires its foreign ID and time")
+	assert.Empty(t, requester.recorded())
+
+	identified := activity
+	identified.ForeignID = "like:1"
+	identified.Time = stream.Time{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	result, err := client.AddToManyBatched(context.Background(), identified, feeds)
+	require.Error(t, err)
+	require.Len(t, requests(), 3)
+	for _, r := range requests() {
+		assert.Equal(t, identified, r.Activity)
+	}
+	failed := result.Failed()
+	require.Len(t, failed, 100)
+	assert.Equal(t, "user:100", failed[0].ID)
+
+	requester.reset()
+	err = client.AddToMany(context.Background(), activity, feeds[:100]...)
+	require.NoError(t, err)
+	require.Len(t, requests(), 1)
+	assert.Equal(t, activity, requests()[0].Activity)
+
+	requester.reset()
+	err = client.AddToMany(context.Background(), identified, feeds...)
+	var batchErr *stream.BatchError
+	require.ErrorAs(t, err, &batchErr)
+	assert.Len(t, requester.recorded(), 3)
+	assert.Len(t, batchErr.Result.Succeeded(), 150)
+	assert.Equal(t, "oops (100 of 250 items failed)", err.Error())
+	apiErr, ok := stream.ToAPIError(errors.Unwrap(err))
+	require.True(t, ok)
+	assert.Equal(t, http.StatusInternalServerError, apiErr.StatusCode)
+}
+
+func TestBatchStopOnErrorInFlight(t *testing.T) {
+	inFlight := make(chan struct{})
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(requesterFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/a1/") {
+			<-inFlight
+			return jsonResponse(http.StatusBadRequest, `{"detail":"bad","status_code":400}`), nil
+		}
+		// in flight until the failure cancels it
+		close(inFlight)
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})))
+	require.NoError(t, err)
+	feed, err := client.FlatFeed("user", "123")
+	require.NoError(t, err)
+
+	result, err := client.RemoveActivitiesByID(context.Background(), feed, []string{"a1", "a2", "a3"},
+		stream.WithBatchConcurrency(2), stream.WithBatchStopOnError())
+	assert.EqualError(t, err, "bad")
+	assert.ErrorIs(t, result.Items[1].Err, stream.ErrBatchUnknown)
+	assert.ErrorIs(t, result.Items[2].Err, stream.ErrBatchSkipped)
+}
+
+func TestFollowManyBatched(t *testing.T) {
+	var limited atomic.Bool
+	client, requester := newRecordingClient(t, func(recordedRequest, int) (*http.Response, error) {
+		if limited.CompareAndSwap(false, true) {
+			return errorResponse(http.StatusTooManyRequests, "rate limited"), nil
+		}
+		return jsonResponse(http.StatusCreated, `{}`), nil
+	})
+	sizes := func() []int {
+		var sizes []int
+		for _, r := range requester.recorded() {
+			var rels []map[string]any
+			require.NoError(t, r.decode(&rels))
+			sizes = append(sizes, len(rels))
+		}
+		return sizes
+	}
+
+	follows := make([]stream.FollowRelationship, 3000)
+	unfollows := make([]stream.UnfollowRelationship, 3000)
+	for i := range follows {
+		follows[i] = stream.FollowRelationship{Source: "timeline:1", Target: fmt.Sprint("user:", i)}
+		unfollows[i] = stream.UnfollowRelationship{Source: "timeline:1", Target: fmt.Sprint("user:", i)}
+	}
+
+	result, err := client.FollowManyBatched(context.Background(), follows, stream.WithBatchConcurrency(1))
+	require.NoError(t, err)
+	// the rate limited chunk is sent again
+	assert.Equal(t, []int{2500, 2500, 500}, sizes())
+	assert.Equal(t, "timeline:1->user:2999", result.Items[2999].ID)
+
+	requester.reset()
+	require.NoError(t, client.UnfollowMany(context.Background(), unfollows))
+	assert.ElementsMatch(t, []int{2500, 500}, sizes())
+	requester.reset()
+	require.NoError(t, client.FollowMany(context.Background(), follows[:10]))
+	assert.Equal(t, []int{10}, sizes())
+
+	// nothing is sent once the context is done
+	requester.reset()
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, client.FollowMany(ctx, follows))
+	assert.Equal(t, context.Canceled, client.UnfollowMany(ctx, unfollows))
+	assert.Empty(t, requester.recorded())
+}
@@ -0,0 +1,79 @@
+package stream
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// Headers set by call options.
+const (
+	HeaderRequestID      = "X-Request-Id"
+	HeaderIdempotencyKey = "Idempotency-Key"
+)
+
+type callOptionsKey struct{}
+
+type callOptions struct {
+	timeout     time.Duration
+	header      http.Header
+	retryPolicy *RetryPolicy
+}
+
+// CallOption customizes a single API call, see WithCallOptions.
+type CallOption func(*callOptions)
+
+// WithCallOptions returns a copy of ctx carrying the given CallOptions, which
+// are applied to any API call performed with it, regardless of the client type
+// used. Options already present in ctx are kept unless overridden.
+func WithCallOptions(ctx context.Context, opts ...CallOption) context.Context {
+	o := callOptionsFromContext(ctx)
+	o.header = o.header.Clone()
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return context.WithValue(ctx, callOptionsKey{}, o)
+}
+
+func callOptionsFromContext(ctx context.Context) callOptions {
+	o, _ := ctx.Value(callOptionsKey{}).(callOptions)
+	return o
+}
+
+// WithCallTimeout sets the timeout of the API call, including retries. It
+// replaces the client-wide timeout, so it can be longer, while any ctx
+// deadline still applies.
+func WithCallTimeout(timeout time.Duration) CallOption {
+	return func(o *callOptions) {
+		o.timeout = timeout
+	}
+}
+
+// WithCallHeader adds an extra header to the API call.
+func WithCallHeader(key, value string) CallOption {
+	return func(o *callOptions) {
+		if o.header == nil {
+			o.header = make(http.Header)
+		}
+		o.header.Set(key, value)
+	}
+}
+
+// WithCallRequestID sets the X-Request-Id header of the API call, useful for
+// tracing requests across services.
+func WithCallRequestID(id string) CallOption {
+	return WithCallHeader(HeaderRequestID, id)
+}
+
+// WithCallIdempotencyKey sets the Idempotency-Key header of the API call. The
+// same key is sent on every retry of the call.
+func WithCallIdempotencyKey(key string) CallOption {
+	return WithCallHeader(HeaderIdempotencyKey, key)
+}
+
+// WithCallRetryPolicy overrides the client's RetryPolicy for the API call.
+func WithCallRetryPolicy(policy RetryPolicy) CallOption {
+	return func(o *callOptions) {
+		o.retryPolicy = &policy
+	}
+}
@@ -0,0 +1,141 @@
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestCallOptions(t *testing.T) {
+	client, requester := newClient(t)
+	ctx := stream.WithCallOptions(context.Background(),
+		stream.WithCallHeader("X-Custom", "foo"),
+		stream.WithCallRequestID("req-1"),
+	)
+	ctx = stream.WithCallOptions(ctx,
+		stream.WithCallIdempotencyKey("key-1"),
+		stream.WithCallTimeout(time.Minute),
+	)
+
+	_, err := client.Reactions().Get(ctx, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, "foo", requester.req.Header.Get("X-Custom"))
+	assert.Equal(t, "req-1", requester.req.Header.Get(stream.HeaderRequestID))
+	assert.Equal(t, "key-1", requester.req.Header.Get(stream.HeaderIdempotencyKey))
+	assert.NotEmpty(t, requester.req.Header.Get("Authorization"))
+	deadline, ok := requester.req.Context().Deadline()
+	require.True(t, ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+
+	_, err = client.Users().Get(context.Background(), "u1")
+	require.NoError(t, err)
+	assert.Empty(t, requester.req.Header.Get("X-Custom"))
+	_, ok = requester.req.Context().Deadline()
+	assert.False(t, ok)
+}
+
+func TestClientWithTimeout(t *testing.T) {
+	client, requester := newRecordingClient(t, nil)
+	_, err := client.Reactions().Get(context.Background(), "r1")
+	require.NoError(t, err)
+	assert.True(t, requester.recorded()[0].deadline.IsZero())
+
+	_, err = client.WithTimeout(time.Minute).Reactions().Get(context.Background(), "r1")
+	require.NoError(t, err)
+	require.Len(t, requester.recorded(), 2)
+	deadline := requester.recorded()[1].deadline
+	assert.WithinDuration(t, time.Now().Add(time.Minute), deadline, 5*time.Second)
+}
+
+// flakyRequester fails the first requests with the status code, or with
+// connection errors for code 0.
+func flakyRequester(failures, code int) *recordingRequester {
+	return &recordingRequester{respond: statusResponder(func(n int) int {
+		if n <= failures {
+			return code
+		}
+		return http.StatusOK
+	})}
+}
+
+func TestRetryPolicy(t *testing.T) {
+	ctx := context.Background()
+	policy := stream.RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+
+	requester := flakyRequester(2, http.StatusServiceUnavailable)
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithRetryPolicy(policy))
+	require.NoError(t, err)
+	_, err = client.Collections().Update(ctx, "food", "1", map[string]any{"name": "cake"})
+	require.NoError(t, err)
+	assert.Equal(t, 3, len(requester.recorded()))
+	assert.Equal(t, requester.recorded()[0].body, requester.recorded()[2].body)
+
+	requester = flakyRequester(1, 0)
+	client, err = stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithRetryPolicy(policy))
+	require.NoError(t, err)
+	_, err = client.Users().Get(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 2, len(requester.recorded()))
+
+	requester = flakyRequester(5, http.StatusTooManyRequests)
+	client, err = stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithRetryPolicy(policy))
+	require.NoError(t, err)
+	_, err = client.Users().Get(ctx, "u1")
+	require.Error(t, err)
+	assert.Equal(t, 3, len(requester.recorded()))
+
+	requester = flakyRequester(1, http.StatusBadRequest)
+	client, err = stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithRetryPolicy(policy))
+	require.NoError(t, err)
+	_, err = client.Users().Get(ctx, "u1")
+	require.Error(t, err)
+	assert.Equal(t, 1, len(requester.recorded()))
+}
+
+func TestRetryPolicyCallOverride(t *testing.T) {
+	requester := flakyRequester(3, http.StatusBadGateway)
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(requester))
+	require.NoError(t, err)
+
+	_, err = client.Users().Get(context.Background(), "u1")
+	require.Error(t, err)
+	assert.Equal(t, 1, len(requester.recorded()))
+
+	ctx := stream.WithCallOptions(context.Background(), stream.WithCallRetryPolicy(stream.RetryPolicy{MaxRetries: 3, Backoff: time.Millisecond}))
+	_, err = client.Users().Get(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, 4, len(requester.recorded()))
+}
+
+func TestRetryPolicyNonIdempotentWrites(t *testing.T) {
+	ctx := context.Background()
+	policy := stream.RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}
+	upsert := func(ctx context.Context, requester *recordingRequester) error {
+		client, err := stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithRetryPolicy(policy))
+		require.NoError(t, err)
+		_, err = client.Collections().Upsert(ctx, "food", stream.CollectionObject{ID: "1"})
+		return err
+	}
+
+	requester := flakyRequester(1, http.StatusServiceUnavailable)
+	require.Error(t, upsert(ctx, requester))
+	assert.Equal(t, 1, len(requester.recorded()))
+
+	requester = flakyRequester(1, 0)
+	require.Error(t, upsert(ctx, requester))
+	assert.Equal(t, 1, len(requester.recorded()))
+
+	requester = flakyRequester(1, http.StatusTooManyRequests)
+	require.NoError(t, upsert(ctx, requester))
+	assert.Equal(t, 2, len(requester.recorded()))
+
+	requester = flakyRequester(1, http.StatusServiceUnavailable)
+	require.NoError(t, upsert(stream.WithCallOptions(ctx, stream.WithCallIdempotencyKey("key-1")), requester))
+	assert.Equal(t, 2, len(requester.recorded()))
+}
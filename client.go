@@ -10,6 +10,7 @@ import (
 	"net/http"
 	"net/url"
 	"os"
+	"reflect"
 	"strings"
 	"time"
 
@@ -19,14 +20,27 @@ import (
 // Client is a Stream API client used for retrieving feeds and performing API
 // calls.
 type Client struct {
-	key           string
-	requester     Requester
-	authenticator authenticator
-	urlBuilder    urlBuilder
-	region        string
-	version       string
-	timeout       time.Duration
-	addr          string
+	key       string
+	requester Requester
+	// enforceTimeout tells whether the client enforces the timeout through
+	// the request context, which it does with the requester built by New and
+	// after WithTimeout.
+	enforceTimeout bool
+	authenticator  authenticator
+	urlBuilder     urlBuilder
+	region         string
+	version        string
+	timeout        time.Duration
+	addr           string
+	compression    *compression
+	retryPolicy    RetryPolicy
+	resolver       EndpointResolver
+	health         *endpointHealth
+	feedGroups     feedGroupRegistry
+
+	strictValidation   bool
+	foreignIDGenerator ForeignIDGenerator
+	filters            contentFilters
 }
 
 // Requester performs HTTP requests.
@@ -48,21 +62,30 @@ func New(key, secret string, opts ...ClientOption) (*Client, error) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if err := c.feedGroups.validate(); err != nil {
+		return nil, err
+	}
 	if c.requester == nil {
-		c.requester = newRequester(c.timeout)
+		c.requester = newRequester()
+		c.enforceTimeout = true
 	}
-	c.urlBuilder = newAPIURLBuilder(c.addr, c.region, c.version)
+	if c.resolver != nil {
+		c.health = newEndpointHealth()
+	}
+	c.urlBuilder = c.productURLBuilder(ProductAPI)
 	return c, nil
 }
 
-func newRequester(timeout time.Duration) Requester {
+// newRequester returns the default requester. It has no timeout, the client
+// enforcing it through the request context so that it can be overridden per
+// call.
+func newRequester() Requester {
 	tr := http.DefaultTransport.(*http.Transport).Clone()
 	tr.MaxIdleConnsPerHost = 5
 	tr.IdleConnTimeout = 59 * time.Second
 	tr.ExpectContinueTimeout = 2 * time.Second
 
 	return &http.Client{
-		Timeout:   timeout,
 		Transport: tr,
 	}
 }
@@ -111,19 +134,44 @@ func WithHTTPRequester(requester Requester) ClientOption {
 	}
 }
 
-// WithTimeout sets the HTTP request timeout
+// WithTimeout sets the timeout of every attempt of an API call, defaulting to
+// 6s. It can be overridden per call with WithCallTimeout and doesn't apply to
+// custom requesters, which enforce their own, unless set with
+// Client.WithTimeout.
 func WithTimeout(dur time.Duration) ClientOption {
 	return func(c *Client) {
 		c.timeout = dur
 	}
 }
 
-// WithTimeout clones the client with the given timeout.
-// If a custom requester was given while initializing, it will be overridden.
+// WithCompression enables gzip compression for the given Client: request bodies
+// whose size is at least threshold bytes are compressed, and compressed
+// responses are requested from the API. A threshold lower or equal to zero
+// uses the default of 1KB.
+func WithCompression(threshold int) ClientOption {
+	return func(c *Client) {
+		if threshold <= 0 {
+			threshold = defaultCompressionThreshold
+		}
+		c.compression = &compression{threshold: threshold}
+	}
+}
+
+// WithRetryPolicy sets the default RetryPolicy used by the given Client. It
+// can be overridden for single API calls with WithCallOptions.
+func WithRetryPolicy(policy RetryPolicy) ClientOption {
+	return func(c *Client) {
+		c.retryPolicy = policy
+	}
+}
+
+// WithTimeout clones the client with the given timeout, enforced on every
+// attempt of an API call through the request context, also when a custom
+// requester was given while initializing.
 func (c *Client) WithTimeout(timeout time.Duration) *Client {
 	nc := *c
 	nc.timeout = timeout
-	nc.requester = newRequester(timeout)
+	nc.enforceTimeout = true
 	return &nc
 }
 
@@ -133,6 +181,9 @@ func (c *Client) FlatFeed(slug, userID string) (*FlatFeed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := c.feedGroups.checkType(slug, FeedTypeFlat); err != nil {
+		return nil, err
+	}
 	return &FlatFeed{*feed}, nil
 }
 
@@ -143,6 +194,9 @@ func (c *Client) AggregatedFeed(slug, userID string) (*AggregatedFeed, error) {
 	if err != nil {
 		return nil, err
 	}
+	if err := c.feedGroups.checkType(slug, FeedTypeAggregated); err != nil {
+		return nil, err
+	}
 	return &AggregatedFeed{*feed}, nil
 }
 
@@ -153,21 +207,86 @@ func (c *Client) NotificationFeed(slug, userID string) (*NotificationFeed, error
 	if err != nil {
 		return nil, err
 	}
+	if err := c.feedGroups.checkType(slug, FeedTypeNotification); err != nil {
+		return nil, err
+	}
 	return &NotificationFeed{*feed}, nil
 }
 
 // GenericFeed returns a standard Feed implementation using the provided target id.
+// If feed groups are registered with WithFeedGroups, the returned Feed is a
+// *FlatFeed, *AggregatedFeed or *NotificationFeed according to the group type.
 func (c *Client) GenericFeed(targetID string) (Feed, error) {
-	parts := strings.Split(targetID, feedSlugIDSeparator)
-	if len(parts) != 2 {
-		return nil, fmt.Errorf("invalid target id: %q", targetID)
+	id, err := ParseFeedID(targetID)
+	if err != nil {
+		return nil, err
+	}
+	if c.feedGroups == nil {
+		return newFeed(id.Slug, id.UserID, c)
+	}
+	g, err := c.feedGroups.lookup(id.Slug)
+	if err != nil {
+		return nil, err
+	}
+	feed, err := newFeed(id.Slug, id.UserID, c)
+	if err != nil {
+		return nil, err
 	}
+	switch g.Type {
+	case FeedTypeAggregated:
+		return &AggregatedFeed{*feed}, nil
+	case FeedTypeNotification:
+		return &NotificationFeed{*feed}, nil
+	default:
+		return &FlatFeed{*feed}, nil
+	}
+}
 
-	return newFeed(parts[0], parts[1], c)
+// FlatFeedFromID returns a new Flat Feed with the provided FeedID.
+func (c *Client) FlatFeedFromID(id FeedID) (*FlatFeed, error) {
+	return c.FlatFeed(id.Slug, id.UserID)
+}
+
+// AggregatedFeedFromID returns a new Aggregated Feed with the provided FeedID.
+func (c *Client) AggregatedFeedFromID(id FeedID) (*AggregatedFeed, error) {
+	return c.AggregatedFeed(id.Slug, id.UserID)
+}
+
+// NotificationFeedFromID returns a new Notification Feed with the provided
+// FeedID.
+func (c *Client) NotificationFeedFromID(id FeedID) (*NotificationFeed, error) {
+	return c.NotificationFeed(id.Slug, id.UserID)
 }
 
 // AddToMany adds an activity to multiple feeds at once.
+// Lists of more than 100 feeds are split in concurrent requests, see
+// AddToManyBatched, and partial failures are reported with a *BatchError.
 func (c *Client) AddToMany(ctx context.Context, activity Activity, feeds ...Feed) error {
+	if err := c.validateActivities(activity); err != nil {
+		return err
+	}
+	flagged, err := c.screenActivities(ctx, activity)
+	if err != nil {
+		return err
+	}
+	if len(feeds) <= maxAddToManyFeeds {
+		if err := c.addToMany(ctx, activity, feeds); err != nil {
+			return err
+		}
+		c.flagActivities(ctx, []Activity{activity}, flagged)
+		return nil
+	}
+	result, err := c.addToManyBatched(ctx, activity, feeds, nil)
+	if err != nil && result == nil {
+		return err
+	}
+	if len(result.Succeeded()) > 0 {
+		c.flagActivities(ctx, []Activity{activity}, flagged)
+	}
+	return batchError(ctx, result)
+}
+
+func (c *Client) addToMany(ctx context.Context, activity Activity, feeds []Feed) error {
 	endpoint := c.makeEndpoint("feed/add_to_many/")
 	ids := make([]string, len(feeds))
 	for i := range feeds {
@@ -177,76 +296,112 @@ func (c *Client) AddToMany(ctx context.Context, activity Activity, feeds ...Feed
 		Activity: activity,
 		FeedIDs:  ids,
 	}
-	_, err := c.post(ctx, endpoint, req, c.authenticator.feedAuth(resFeed, nil))
-	return err
+	return c.post(ctx, endpoint, req, nil, c.authenticator.feedAuth(resFeed, nil))
 }
 
-// FollowMany creates multiple follows at once.
+// FollowMany creates multiple follows at once. Lists of more than 2500
+// relationships are split in concurrent requests, see FollowManyBatched, and
+// partial failures are reported with a *BatchError.
 func (c *Client) FollowMany(ctx context.Context, relationships []FollowRelationship, opts ...FollowManyOption) error {
+	if c.feedGroups != nil {
+		for _, r := range relationships {
+			if err := c.checkFollow(r.Source, r.Target); err != nil {
+				return err
+			}
+		}
+	}
+	if len(relationships) > maxFollowManyRelationships {
+		result, err := c.followManyBatched(ctx, relationships, opts, nil)
+		if err != nil && result == nil {
+			return err
+		}
+		return batchError(ctx, result)
+	}
+	return c.followMany(ctx, relationships, opts...)
+}
+
+func (c *Client) followMany(ctx context.Context, relationships []FollowRelationship, opts ...FollowManyOption) error {
 	endpoint := c.makeEndpoint("follow_many/")
 	for _, opt := range opts {
 		endpoint.addQueryParam(opt)
 	}
-	_, err := c.post(ctx, endpoint, relationships, c.authenticator.feedAuth(resFollower, nil))
-	return err
+	return c.post(ctx, endpoint, relationships, nil, c.authenticator.feedAuth(resFollower, nil))
 }
 
-// UnfollowMany removes multiple follow relationships at once.
+// UnfollowMany removes multiple follow relationships at once. Lists of more
+// than 2500 relationships are split in concurrent requests, see
+// UnfollowManyBatched, and partial failures are reported with a *BatchError.
 func (c *Client) UnfollowMany(ctx context.Context, relationships []UnfollowRelationship) error {
+	if len(relationships) > maxUnfollowManyRelationships {
+		result, err := c.UnfollowManyBatched(ctx, relationships)
+		if err != nil && result == nil {
+			return err
+		}
+		return batchError(ctx, result)
+	}
+	return c.unfollowMany(ctx, relationships)
+}
+
+func (c *Client) unfollowMany(ctx context.Context, relationships []UnfollowRelationship) error {
 	endpoint := c.makeEndpoint("unfollow_many/")
-	_, err := c.post(ctx, endpoint, relationships, c.authenticator.feedAuth(resFollower, nil))
-	return err
+	return c.post(ctx, endpoint, relationships, nil, c.authenticator.feedAuth(resFollower, nil))
 }
 
 func (c *Client) cloneWithURLBuilder(builder urlBuilder) *Client {
-	return &Client{
-		key:           c.key,
-		requester:     c.requester,
-		authenticator: c.authenticator,
-		urlBuilder:    builder,
+	nc := *c
+	nc.urlBuilder = builder
+	return &nc
+}
+
+// productURLBuilder returns the urlBuilder for the given product, backed by
+// the EndpointResolver if set.
+func (c *Client) productURLBuilder(product Product) urlBuilder {
+	if c.resolver != nil {
+		return resolverURLBuilder{resolver: c.resolver, product: product}
+	}
+	switch product {
+	case ProductAnalytics:
+		return newAnalyticsURLBuilder(c.region, c.version)
+	case ProductPersonalization:
+		return newPersonalizationURLBuilder(c.region)
+	default:
+		return newAPIURLBuilder(c.addr, c.region, c.version)
 	}
 }
 
 // Analytics returns a new AnalyticsClient sharing the base configuration of the original Client.
 func (c *Client) Analytics() *AnalyticsClient {
-	b := newAnalyticsURLBuilder(c.region, c.version)
-	return &AnalyticsClient{client: c.cloneWithURLBuilder(b)}
+	return &AnalyticsClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductAnalytics))}
 }
 
 // Collections returns a new CollectionsClient.
 func (c *Client) Collections() *CollectionsClient {
-	b := newAPIURLBuilder(c.addr, c.region, c.version)
-	return &CollectionsClient{client: c.cloneWithURLBuilder(b)}
+	return &CollectionsClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductAPI))}
 }
 
 // Users returns a new UsersClient.
 func (c *Client) Users() *UsersClient {
-	b := newAPIURLBuilder(c.addr, c.region, c.version)
-	return &UsersClient{client: c.cloneWithURLBuilder(b)}
+	return &UsersClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductAPI))}
 }
 
 // Reactions returns a new ReactionsClient.
 func (c *Client) Reactions() *ReactionsClient {
-	b := newAPIURLBuilder(c.addr, c.region, c.version)
-	return &ReactionsClient{client: c.cloneWithURLBuilder(b)}
+	return &ReactionsClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductAPI))}
 }
 
 // Moderation returns a new ModerationClient.
 func (c *Client) Moderation() *ModerationClient {
-	b := newAPIURLBuilder(c.addr, c.region, c.version)
-	return &ModerationClient{client: c.cloneWithURLBuilder(b)}
+	return &ModerationClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductAPI))}
 }
 
 // AuditLogs returns a new AuditLogsClient.
 func (c *Client) AuditLogs() *AuditLogsClient {
-	b := newAPIURLBuilder(c.addr, c.region, c.version)
-	return &AuditLogsClient{client: c.cloneWithURLBuilder(b)}
+	return &AuditLogsClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductAPI))}
 }
 
 // Personalization returns a new PersonalizationClient.
 func (c *Client) Personalization() *PersonalizationClient {
-	b := newPersonalizationURLBuilder(c.region)
-	return &PersonalizationClient{client: c.cloneWithURLBuilder(b)}
+	return &PersonalizationClient{client: c.cloneWithURLBuilder(c.productURLBuilder(ProductPersonalization))}
 }
 
 // GetActivitiesByID returns activities for the current app having the given IDs.
@@ -273,13 +428,8 @@ func (c *Client) getAppActivities(ctx context.Context, values ...valuer) (*GetAc
 	for _, v := range values {
 		endpoint.addQueryParam(v)
 	}
-	data, err := c.get(ctx, endpoint, nil, c.authenticator.feedAuth(resActivities, nil))
-	if err != nil {
-		return nil, err
-	}
 	var resp GetActivitiesResponse
-	err = json.Unmarshal(data, &resp)
-	if err != nil {
+	if err := c.get(ctx, endpoint, nil, &resp, c.authenticator.feedAuth(resActivities, nil)); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -299,13 +449,8 @@ func (c *Client) GetReactions(ctx context.Context, ids []string, opts ...GetReac
 	for _, opt := range opts {
 		endpoint.addQueryParam(opt)
 	}
-	data, err := c.get(ctx, endpoint, nil, c.authenticator.reactionsAuth)
-	if err != nil {
-		return nil, err
-	}
 	var resp GetReactionsByIDsResponse
-	err = json.Unmarshal(data, &resp)
-	if err != nil {
+	if err := c.get(ctx, endpoint, nil, &resp, c.authenticator.reactionsAuth); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -332,13 +477,8 @@ func (c *Client) getAppEnrichedActivities(ctx context.Context, options ...GetAct
 	for _, v := range options {
 		endpoint.addQueryParam(v.requestOption)
 	}
-	data, err := c.get(ctx, endpoint, nil, c.authenticator.feedAuth(resActivities, nil))
-	if err != nil {
-		return nil, err
-	}
 	var resp GetEnrichedActivitiesResponse
-	err = json.Unmarshal(data, &resp)
-	if err != nil {
+	if err := c.get(ctx, endpoint, nil, &resp, c.authenticator.feedAuth(resActivities, nil)); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -346,33 +486,46 @@ func (c *Client) getAppEnrichedActivities(ctx context.Context, options ...GetAct
 
 // UpdateActivities updates existing activities.
 func (c *Client) UpdateActivities(ctx context.Context, activities ...Activity) (*BaseResponse, error) {
+	if err := c.validateActivities(activities...); err != nil {
+		return nil, err
+	}
+	flagged, err := c.screenActivities(ctx, activities...)
+	if err != nil {
+		return nil, err
+	}
 	req := struct {
 		Activities []Activity `json:"activities,omitempty"`
 	}{
 		Activities: activities,
 	}
 	endpoint := c.makeEndpoint("activities/")
-	return decode(c.post(ctx, endpoint, req, c.authenticator.feedAuth(resActivities, nil)))
+	var resp BaseResponse
+	if err := c.post(ctx, endpoint, req, &resp, c.authenticator.feedAuth(resActivities, nil)); err != nil {
+		return nil, err
+	}
+	c.flagActivities(ctx, activities, flagged)
+	return &resp, nil
 }
 
 // PartialUpdateActivities performs a partial update on multiple activities with the given set and unset operations
 // specified by each changeset. This returns the affected activities.
 func (c *Client) PartialUpdateActivities(ctx context.Context, changesets ...UpdateActivityRequest) (*UpdateActivitiesResponse, error) {
+	activities, flagged, err := c.screenChangesets(ctx, changesets...)
+	if err != nil {
+		return nil, err
+	}
 	req := struct {
 		Activities []UpdateActivityRequest `json:"changes,omitempty"`
 	}{
 		Activities: changesets,
 	}
 	endpoint := c.makeEndpoint("activity/")
-	data, err := c.post(ctx, endpoint, req, c.authenticator.feedAuth(resActivities, nil))
-	if err != nil {
-		return nil, err
-	}
 	var resp UpdateActivitiesResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.post(ctx, endpoint, req, &resp, c.authenticator.feedAuth(resActivities, nil)); err != nil {
 		return nil, err
 	}
-	return &resp, err
+	c.flagActivities(ctx, activities, flagged)
+	return &resp, nil
 }
 
 // UpdateActivityByID performs a partial activity update with the given set and unset operations, returning the
@@ -397,15 +550,19 @@ func (c *Client) UpdateActivityByForeignID(ctx context.Context, foreignID string
 }
 
 func (c *Client) updateActivity(ctx context.Context, req UpdateActivityRequest) (*UpdateActivityResponse, error) {
-	endpoint := c.makeEndpoint("activity/")
-	data, err := c.post(ctx, endpoint, req, c.authenticator.feedAuth(resActivities, nil))
+	activities, flagged, err := c.screenChangesets(ctx, req)
 	if err != nil {
 		return nil, err
 	}
+	endpoint := c.makeEndpoint("activity/")
 	var resp UpdateActivityResponse
-	if err := json.Unmarshal(data, &resp); err != nil {
+	if err := c.post(ctx, endpoint, req, &resp, c.authenticator.feedAuth(resActivities, nil)); err != nil {
 		return nil, err
 	}
+	if len(flagged) > 0 && resp.ID != "" {
+		activities[0].ID = resp.ID
+	}
+	c.flagActivities(ctx, activities, flagged)
 	return &resp, nil
 }
 
@@ -419,7 +576,12 @@ func (c *Client) makeStreamError(statusCode int, rate *Rate, body io.Reader) err
 	}
 	var streamErr APIError
 	if err := json.Unmarshal(errBody, &streamErr); err != nil {
-		return fmt.Errorf("unexpected error (status code %d)", statusCode)
+		// keep the status code around for non-JSON errors, as returned by proxies
+		return APIError{
+			Detail:     fmt.Sprintf("unexpected error (status code %d)", statusCode),
+			StatusCode: statusCode,
+			Rate:       rate,
+		}
 	}
 	streamErr.StatusCode = statusCode
 	streamErr.Rate = rate
@@ -429,9 +591,18 @@ func (c *Client) makeStreamError(statusCode int, rate *Rate, body io.Reader) err
 type endpoint struct {
 	url   *url.URL
 	query url.Values
+	// fallbacks are the same endpoint on the alternate hosts resolved by an
+	// EndpointResolver, if any.
+	fallbacks []*url.URL
+	// err is set when no valid URL could be built, and returned by any call
+	// to the endpoint.
+	err error
 }
 
 func (e endpoint) String() string {
+	if e.url == nil {
+		return ""
+	}
 	e.url.RawQuery = e.query.Encode()
 	return e.url.String()
 }
@@ -444,121 +615,298 @@ func (e *endpoint) addQueryParam(v valuer) {
 }
 
 func (c *Client) makeEndpoint(format string, a ...any) endpoint {
-	host := c.urlBuilder.url()
-
 	path := fmt.Sprintf(format, a...)
-	u, _ := url.Parse(host + path)
 
 	query := make(url.Values)
 	query.Set("api_key", c.key)
 
+	var hosts []string
+	if b, ok := c.urlBuilder.(resolverURLBuilder); ok {
+		hosts = b.urls()
+		if len(hosts) == 0 {
+			return endpoint{query: query, err: fmt.Errorf("no endpoints resolved for product %q", b.product)}
+		}
+	} else {
+		hosts = []string{c.urlBuilder.url()}
+	}
+	u, err := url.Parse(hosts[0] + path)
+	if err != nil {
+		return endpoint{query: query, err: fmt.Errorf("invalid endpoint %q: %w", hosts[0], err)}
+	}
+
+	var fallbacks []*url.URL
+	for _, host := range hosts[1:] {
+		if fu, err := url.Parse(host + path); err == nil {
+			fallbacks = append(fallbacks, fu)
+		}
+	}
+
 	return endpoint{
-		url:   u,
-		query: query,
+		url:       u,
+		query:     query,
+		fallbacks: fallbacks,
 	}
 }
 
-func (c *Client) get(ctx context.Context, endpoint endpoint, data any, authFn authFunc) ([]byte, error) {
-	return c.request(ctx, http.MethodGet, endpoint, data, authFn)
+// candidates returns the URLs to try for a request, in order. Read requests
+// prefer the hosts which didn't fail recently, while writes always target the
+// primary host first.
+func (e endpoint) candidates(method string, health *endpointHealth) []*url.URL {
+	urls := append([]*url.URL{e.url}, e.fallbacks...)
+	if isReadMethod(method) {
+		return health.order(urls)
+	}
+	return urls
 }
 
-func (c *Client) post(ctx context.Context, endpoint endpoint, data any, authFn authFunc) ([]byte, error) {
-	return c.request(ctx, http.MethodPost, endpoint, data, authFn)
+func (c *Client) get(ctx context.Context, endpoint endpoint, data, out any, authFn authFunc) error {
+	return c.request(ctx, http.MethodGet, endpoint, data, out, authFn)
 }
 
-func (c *Client) put(ctx context.Context, endpoint endpoint, data any, authFn authFunc) ([]byte, error) {
-	return c.request(ctx, http.MethodPut, endpoint, data, authFn)
+func (c *Client) post(ctx context.Context, endpoint endpoint, data, out any, authFn authFunc) error {
+	return c.request(ctx, http.MethodPost, endpoint, data, out, authFn)
 }
 
-func (c *Client) delete(ctx context.Context, endpoint endpoint, data any, authFn authFunc) ([]byte, error) {
-	return c.request(ctx, http.MethodDelete, endpoint, data, authFn)
+func (c *Client) put(ctx context.Context, endpoint endpoint, data, out any, authFn authFunc) error {
+	return c.request(ctx, http.MethodPut, endpoint, data, out, authFn)
+}
+
+func (c *Client) delete(ctx context.Context, endpoint endpoint, data, out any, authFn authFunc) error {
+	return c.request(ctx, http.MethodDelete, endpoint, data, out, authFn)
 }
 
 func (c *Client) setBaseHeaders(r *http.Request) {
 	r.Header.Set("Content-type", "application/json")
 	r.Header.Set("X-Stream-Client", fmt.Sprintf("stream-go2-client-%s", Version))
+	if c.compression != nil {
+		r.Header.Set("Accept-Encoding", "gzip")
+	}
 }
 
-func (c *Client) request(ctx context.Context, method string, endpoint endpoint, data any, authFn authFunc) ([]byte, error) {
-	var reader io.Reader
+// request performs the API call and decodes the response body straight into
+// out, if not nil. The rate limit information is taken from the response
+// headers and attached to out when it embeds the common response fields.
+// Failed attempts are retried according to the client's RetryPolicy, or to the
+// one set with WithCallOptions on ctx.
+func (c *Client) request(ctx context.Context, method string, endpoint endpoint, data, out any, authFn authFunc) error {
+	if endpoint.err != nil {
+		return endpoint.err
+	}
+	opts := callOptionsFromContext(ctx)
+	// without a call timeout, the client-wide one bounds every attempt
+	attemptTimeout := c.attemptTimeout()
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+		attemptTimeout = 0
+	}
+
+	var (
+		payload  []byte
+		encoding string
+	)
 	if data != nil {
-		payload, err := json.Marshal(data)
+		var err error
+		payload, err = json.Marshal(data)
 		if err != nil {
-			return nil, fmt.Errorf("cannot marshal request: %w", err)
+			return fmt.Errorf("cannot marshal request: %w", err)
+		}
+		if c.compression != nil && len(payload) >= c.compression.threshold {
+			if payload, err = gzipPayload(payload); err != nil {
+				return fmt.Errorf("cannot compress request: %w", err)
+			}
+			encoding = "gzip"
 		}
+	}
+
+	policy := c.retryPolicy
+	if opts.retryPolicy != nil {
+		policy = *opts.retryPolicy
+	}
+	candidates := endpoint.candidates(method, c.health)
+	for attempt := 0; ; {
+		endpoint.url = candidates[0]
+		resetOut(out)
+		attemptCtx, cancel := ctx, context.CancelFunc(func() {})
+		if attemptTimeout > 0 {
+			attemptCtx, cancel = context.WithTimeout(ctx, attemptTimeout)
+		}
+		err := c.do(attemptCtx, method, endpoint, payload, encoding, opts.header, out, authFn)
+		cancel()
+		c.health.report(ctx, endpoint.url, err)
+		switch {
+		case err == nil:
+			return nil
+		case len(candidates) > 1 && isReadMethod(method) && isFailoverError(ctx, err):
+			candidates = candidates[1:]
+			continue
+		case attempt >= policy.MaxRetries || !canRetry(ctx, method, opts.header, err):
+			return err
+		}
+		if err := policy.wait(ctx, attempt); err != nil {
+			return err
+		}
+		attempt++
+	}
+}
+
+// attemptTimeout returns the client-wide timeout, enforced through the
+// request context since it's not set on the default requester. Custom
+// requesters enforce their own timeouts, unless set with WithTimeout.
+func (c *Client) attemptTimeout() time.Duration {
+	if !c.enforceTimeout {
+		return 0
+	}
+	return c.timeout
+}
+
+// resetOut zeroes the value pointed to by out, so that an attempt doesn't
+// decode over the response of a previous one.
+func resetOut(out any) {
+	v := reflect.ValueOf(out)
+	if v.Kind() == reflect.Pointer && !v.IsNil() {
+		v.Elem().SetZero()
+	}
+}
+
+// do performs a single attempt of an API call.
+func (c *Client) do(ctx context.Context, method string, endpoint endpoint, payload []byte, encoding string, header http.Header, out any, authFn authFunc) error {
+	var reader io.Reader
+	if payload != nil {
 		reader = bytes.NewReader(payload)
 	}
 
 	req, err := http.NewRequestWithContext(ctx, method, endpoint.String(), reader)
 	if err != nil {
-		return nil, fmt.Errorf("cannot create request: %w", err)
+		return fmt.Errorf("cannot create request: %w", err)
 	}
 	c.setBaseHeaders(req)
+	if encoding != "" {
+		req.Header.Set("Content-Encoding", encoding)
+	}
+	for k, v := range header {
+		req.Header[k] = v
+	}
 
 	if authFn != nil {
 		if err := authFn(req); err != nil {
-			return nil, err
+			return err
 		}
 	}
 
 	resp, err := c.requester.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("cannot perform request: %w", err)
+		return transportError{err: err}
 	}
 	defer resp.Body.Close()
 
 	rate := NewRate(resp.Header)
 
 	if resp.StatusCode/100 != 2 {
-		return nil, c.makeStreamError(resp.StatusCode, rate, resp.Body)
+		body, err := decompressBody(resp)
+		if err != nil {
+			// keep the status code around for undecodable error bodies
+			return c.makeStreamError(resp.StatusCode, rate, http.NoBody)
+		}
+		defer body.Close()
+		return c.makeStreamError(resp.StatusCode, rate, body)
 	}
 
-	body, err := io.ReadAll(resp.Body)
+	body, err := decompressBody(resp)
 	if err != nil {
-		return nil, fmt.Errorf("cannot read response: %w", err)
+		return fmt.Errorf("cannot read response: %w", err)
 	}
+	defer body.Close()
 
-	out := map[string]any{}
-	if err := json.Unmarshal(body, &out); err != nil {
-		return nil, fmt.Errorf("cannot read response: %w", err)
+	if out == nil {
+		if _, err := io.Copy(io.Discard, body); err != nil {
+			return fmt.Errorf("cannot read response: %w", err)
+		}
+		return nil
 	}
 
-	out["ratelimit"] = rate
-
-	if body, err = json.Marshal(out); err != nil {
-		return nil, fmt.Errorf("cannot read response: %w", err)
+	if err := json.NewDecoder(body).Decode(out); err != nil {
+		return fmt.Errorf("cannot read response: %w", err)
 	}
 
-	return body, nil
+	if r, ok := out.(rateSetter); ok {
+		r.setRate(rate)
+	}
+	return nil
 }
 
 func (c *Client) addActivity(ctx context.Context, feed Feed, activity Activity) (*AddActivityResponse, error) {
-	endpoint := c.makeEndpoint("feed/%s/%s/", feed.Slug(), feed.UserID())
-	resp, err := c.post(ctx, endpoint, activity, c.authenticator.feedAuth(resFeed, feed))
+	activity = c.prepareIdempotent(activity)[0]
+	if err := c.validateActivities(activity); err != nil {
+		return nil, err
+	}
+	flagged, err := c.screenActivities(ctx, activity)
 	if err != nil {
 		return nil, err
 	}
+	endpoint := c.makeEndpoint("feed/%s/%s/", feed.Slug(), feed.UserID())
 	var out AddActivityResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	add := func(ctx context.Context, activities []Activity) ([]Activity, error) {
+		out = AddActivityResponse{}
+		if err := c.post(ctx, endpoint, activities[0], &out, c.authenticator.feedAuth(resFeed, feed)); err != nil {
+			return nil, err
+		}
+		return []Activity{out.Activity}, nil
+	}
+	if c.foreignIDGenerator == nil {
+		_, err = add(ctx, []Activity{activity})
+	} else {
+		var stored []Activity
+		if stored, err = c.addIdempotent(ctx, []Activity{activity}, add); err == nil {
+			out.Activity = stored[0]
+		}
+	}
+	if err != nil {
 		return nil, err
 	}
+	c.flagActivities(ctx, []Activity{out.Activity}, flagged)
 	return &out, nil
 }
 
 func (c *Client) addActivities(ctx context.Context, feed Feed, activities ...Activity) (*AddActivitiesResponse, error) {
-	reqBody := struct {
-		Activities []Activity `json:"activities,omitempty"`
-	}{
-		Activities: activities,
+	activities = c.prepareIdempotent(activities...)
+	if err := c.validateActivities(activities...); err != nil {
+		return nil, err
 	}
-	endpoint := c.makeEndpoint("feed/%s/%s/", feed.Slug(), feed.UserID())
-	resp, err := c.post(ctx, endpoint, reqBody, c.authenticator.feedAuth(resFeed, feed))
+	flagged, err := c.screenActivities(ctx, activities...)
 	if err != nil {
 		return nil, err
 	}
+	endpoint := c.makeEndpoint("feed/%s/%s/", feed.Slug(), feed.UserID())
 	var out AddActivitiesResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal response: %w", err)
+	add := func(ctx context.Context, activities []Activity) ([]Activity, error) {
+		reqBody := struct {
+			Activities []Activity `json:"activities,omitempty"`
+		}{
+			Activities: activities,
+		}
+		out = AddActivitiesResponse{}
+		if err := c.post(ctx, endpoint, reqBody, &out, c.authenticator.feedAuth(resFeed, feed)); err != nil {
+			return nil, err
+		}
+		return out.Activities, nil
+	}
+	if c.foreignIDGenerator == nil {
+		_, err = add(ctx, activities)
+	} else {
+		var stored []Activity
+		if stored, err = c.addIdempotent(ctx, activities, add); err == nil {
+			out.Activities = stored
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(out.Activities) == len(activities) {
+		c.flagActivities(ctx, out.Activities, flagged)
+	} else {
+		c.flagActivities(ctx, activities, flagged)
 	}
 	return &out, nil
 }
@@ -568,12 +916,8 @@ func (c *Client) removeActivityByID(ctx context.Context, feed Feed, activityID s
 	for _, opt := range opts {
 		endpoint.addQueryParam(opt)
 	}
-	resp, err := c.delete(ctx, endpoint, nil, c.authenticator.feedAuth(resFeed, feed))
-	if err != nil {
-		return nil, err
-	}
 	var out RemoveActivityResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.delete(ctx, endpoint, nil, &out, c.authenticator.feedAuth(resFeed, feed)); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -582,37 +926,60 @@ func (c *Client) removeActivityByID(ctx context.Context, feed Feed, activityID s
 func (c *Client) removeActivityByForeignID(ctx context.Context, feed Feed, foreignID string) (*RemoveActivityResponse, error) {
 	endpoint := c.makeEndpoint("feed/%s/%s/%s/", feed.Slug(), feed.UserID(), foreignID)
 	endpoint.addQueryParam(makeRequestOption("foreign_id", 1))
-	resp, err := c.delete(ctx, endpoint, nil, c.authenticator.feedAuth(resFeed, feed))
-	if err != nil {
-		return nil, err
-	}
 	var out RemoveActivityResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.delete(ctx, endpoint, nil, &out, c.authenticator.feedAuth(resFeed, feed)); err != nil {
 		return nil, err
 	}
 	return &out, nil
 }
 
-func (c *Client) getActivities(ctx context.Context, feed Feed, opts ...GetActivitiesOption) ([]byte, error) {
+func (c *Client) getActivities(ctx context.Context, feed Feed, out any, opts ...GetActivitiesOption) error {
 	endpoint := c.makeEndpoint("feed/%s/%s/", feed.Slug(), feed.UserID())
-	return c.getActivitiesInternal(ctx, endpoint, feed, opts...)
+	return c.getActivitiesInternal(ctx, endpoint, feed, out, opts...)
 }
 
-func (c *Client) getEnrichedActivities(ctx context.Context, feed Feed, opts ...GetActivitiesOption) ([]byte, error) {
+func (c *Client) getEnrichedActivities(ctx context.Context, feed Feed, out any, opts ...GetActivitiesOption) error {
 	endpoint := c.makeEndpoint("enrich/feed/%s/%s/", feed.Slug(), feed.UserID())
-	return c.getActivitiesInternal(ctx, endpoint, feed, opts...)
+	return c.getActivitiesInternal(ctx, endpoint, feed, out, opts...)
 }
 
-func (c *Client) getActivitiesInternal(ctx context.Context, endpoint endpoint, feed Feed, opts ...GetActivitiesOption) ([]byte, error) {
+func (c *Client) getActivitiesInternal(ctx context.Context, endpoint endpoint, feed Feed, out any, opts ...GetActivitiesOption) error {
 	for _, opt := range opts {
 		endpoint.addQueryParam(opt)
 	}
-	return c.get(ctx, endpoint, nil, c.authenticator.feedAuth(resFeed, feed))
+	if err := c.feedGroups.checkRanking(feed.Slug(), endpoint.query.Get("ranking")); err != nil {
+		return err
+	}
+	return c.get(ctx, endpoint, nil, out, c.authenticator.feedAuth(resFeed, feed))
 }
 
 func (c *Client) follow(ctx context.Context, feed Feed, opts *followFeedOptions) (*BaseResponse, error) {
+	if err := c.checkFollow(feed.ID(), opts.Target); err != nil {
+		return nil, err
+	}
 	endpoint := c.makeEndpoint("feed/%s/%s/follows/", feed.Slug(), feed.UserID())
-	return decode(c.post(ctx, endpoint, opts, c.authenticator.feedAuth(resFollower, feed)))
+	var resp BaseResponse
+	if err := c.post(ctx, endpoint, opts, &resp, c.authenticator.feedAuth(resFollower, feed)); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// checkFollow checks the follow between the given feed IDs against the
+// registered feed groups.
+func (c *Client) checkFollow(source, target string) error {
+	if c.feedGroups == nil {
+		return nil
+	}
+	sid, err := ParseFeedID(source)
+	if err != nil {
+		return err
+	}
+	tid, err := ParseFeedID(target)
+	if err != nil {
+		return err
+	}
+	return c.feedGroups.checkFollow(sid.Slug, tid.Slug)
 }
 
 func (c *Client) getFollowers(ctx context.Context, feed Feed, opts ...FollowersOption) (*FollowersResponse, error) {
@@ -621,12 +988,8 @@ func (c *Client) getFollowers(ctx context.Context, feed Feed, opts ...FollowersO
 		endpoint.addQueryParam(opt)
 	}
 
-	resp, err := c.get(ctx, endpoint, nil, c.authenticator.feedAuth(resFollower, feed))
-	if err != nil {
-		return nil, err
-	}
 	var out FollowersResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.get(ctx, endpoint, nil, &out, c.authenticator.feedAuth(resFollower, feed)); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -638,12 +1001,8 @@ func (c *Client) getFollowing(ctx context.Context, feed Feed, opts ...FollowingO
 		endpoint.addQueryParam(opt)
 	}
 
-	resp, err := c.get(ctx, endpoint, nil, c.authenticator.feedAuth(resFollower, feed))
-	if err != nil {
-		return nil, err
-	}
 	var out FollowingResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.get(ctx, endpoint, nil, &out, c.authenticator.feedAuth(resFollower, feed)); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -655,7 +1014,11 @@ func (c *Client) unfollow(ctx context.Context, feed Feed, target string, opts ..
 		endpoint.addQueryParam(opt)
 	}
 
-	return decode(c.delete(ctx, endpoint, nil, c.authenticator.feedAuth(resFollower, feed)))
+	var resp BaseResponse
+	if err := c.delete(ctx, endpoint, nil, &resp, c.authenticator.feedAuth(resFollower, feed)); err != nil {
+		return nil, err
+	}
+	return &resp, nil
 }
 
 func (c *Client) followStats(ctx context.Context, feed Feed, opts ...FollowStatOption) (*FollowStatResponse, error) {
@@ -666,12 +1029,8 @@ func (c *Client) followStats(ctx context.Context, feed Feed, opts ...FollowStatO
 		endpoint.addQueryParam(opt)
 	}
 
-	resp, err := c.get(ctx, endpoint, nil, c.authenticator.feedAuth(resFollower, nil))
-	if err != nil {
-		return nil, err
-	}
 	var out FollowStatResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.get(ctx, endpoint, nil, &out, c.authenticator.feedAuth(resFollower, nil)); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -692,12 +1051,8 @@ func (c *Client) updateToTargets(ctx context.Context, feed Feed, activity Activi
 		opt(req)
 	}
 
-	resp, err := c.post(ctx, endpoint, req, c.authenticator.feedAuth(resFeedTargets, feed))
-	if err != nil {
-		return nil, err
-	}
 	var out UpdateToTargetsResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.post(ctx, endpoint, req, &out, c.authenticator.feedAuth(resFeedTargets, feed)); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -724,12 +1079,8 @@ func (c *Client) batchUpdateToTargets(ctx context.Context, feed Feed, reqs []Upd
 		convertedReqs = append(convertedReqs, rr)
 	}
 
-	resp, err := c.post(ctx, endpoint, convertedReqs, c.authenticator.feedAuth(resFeedTargets, feed))
-	if err != nil {
-		return nil, err
-	}
 	var out UpdateToTargetsResponse
-	if err := json.Unmarshal(resp, &out); err != nil {
+	if err := c.post(ctx, endpoint, convertedReqs, &out, c.authenticator.feedAuth(resFeedTargets, feed)); err != nil {
 		return nil, err
 	}
 	return &out, nil
@@ -1,7 +1,9 @@
 package stream
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
@@ -242,8 +244,194 @@ func Test_requestErrors(t *testing.T) {
 
 	for _, tc := range testCases {
 		c := &Client{requester: tc.requester}
-		_, err := c.request(ctx, tc.method, endpoint{url: &url.URL{}, query: url.Values{}}, tc.data, tc.authFn)
+		var out BaseResponse
+		err := c.request(ctx, tc.method, endpoint{url: &url.URL{}, query: url.Values{}}, tc.data, &out, tc.authFn)
 		require.Error(t, err)
 		assert.Equal(t, tc.expected.Error(), err.Error())
 	}
 }
+
+type bodyRequester struct {
+	header http.Header
+	body   []byte
+}
+
+func (r bodyRequester) Do(*http.Request) (*http.Response, error) {
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     r.header,
+		Body:       io.NopCloser(bytes.NewReader(r.body)),
+	}, nil
+}
+
+func Test_requestRate(t *testing.T) {
+	ctx := context.Background()
+	header := http.Header{}
+	header.Set(HeaderRateLimit, "100")
+	header.Set(HeaderRateRemaining, "42")
+	header.Set(HeaderRateReset, "1700000000")
+	c := &Client{requester: bodyRequester{header: header, body: []byte(`{"duration":"12ms","results":[{"actor":"bob","verb":"like","object":"cake","ratelimit":1}]}`)}}
+
+	var resp FlatFeedResponse
+	err := c.request(ctx, http.MethodGet, endpoint{url: &url.URL{}, query: url.Values{}}, nil, &resp, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 100, resp.Rate.Limit)
+	assert.Equal(t, 42, resp.Rate.Remaining)
+	assert.Equal(t, int64(1700000000), resp.Rate.Reset.Unix())
+	assert.Equal(t, 12*time.Millisecond, resp.Duration.Duration)
+	require.Len(t, resp.Results, 1)
+	assert.Equal(t, map[string]any{"ratelimit": 1.0}, resp.Results[0].Extra)
+
+	var personalization PersonalizationResponse
+	err = c.request(ctx, http.MethodGet, endpoint{url: &url.URL{}, query: url.Values{}}, nil, &personalization, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 42, personalization.Rate.Remaining)
+
+	err = c.request(ctx, http.MethodGet, endpoint{url: &url.URL{}, query: url.Values{}}, nil, nil, nil)
+	require.NoError(t, err)
+}
+
+// legacyRemarshal reproduces the former response pipeline, which injected the
+// rate limit by decoding the body into a map and encoding it back.
+func legacyRemarshal(body []byte, rate *Rate) ([]byte, error) {
+	out := map[string]any{}
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, err
+	}
+	out["ratelimit"] = rate
+	return json.Marshal(out)
+}
+
+// legacyEnrichedActivity decodes through mapstructure, as EnrichedActivity did
+// before being decoded directly from the payload.
+type legacyEnrichedActivity EnrichedActivity
+
+func (a *legacyEnrichedActivity) UnmarshalJSON(b []byte) error {
+	var data map[string]any
+	if err := json.Unmarshal(b, &data); err != nil {
+		return err
+	}
+	if tos, ok := data["to"].([]any); ok {
+		simpleTos := make([]string, len(tos))
+		for i := range tos {
+			simpleTos[i], _ = tos[i].(string)
+		}
+		data["to"] = simpleTos
+	}
+	meta, err := decodeData(data, (*EnrichedActivity)(a))
+	if err != nil {
+		return err
+	}
+	if len(meta.Unused) > 0 {
+		a.Extra = make(map[string]any)
+		for _, k := range meta.Unused {
+			a.Extra[k] = data[k]
+		}
+	}
+	return nil
+}
+
+type legacyEnrichedFlatFeedResponse struct {
+	readResponse
+	Results []legacyEnrichedActivity `json:"results,omitempty"`
+}
+
+func benchmarkEnrichedFeedBody(b *testing.B, size int) []byte {
+	results := make([]map[string]any, size)
+	for i := range results {
+		results[i] = map[string]any{
+			"id":              fmt.Sprintf("a0b1c2d3-%d", i),
+			"actor":           map[string]any{"id": "bob", "name": "Bob", "avatar": "https://example.com/bob.png"},
+			"verb":            "post",
+			"object":          map[string]any{"id": fmt.Sprintf("post:%d", i), "text": strings.Repeat("lorem ipsum ", 10)},
+			"foreign_id":      fmt.Sprintf("post:%d", i),
+			"time":            "2024-01-02T15:04:05.999999",
+			"to":              []string{"timeline:alice", "notification:carol"},
+			"popularity":      i,
+			"tags":            []string{"go", "stream", "feeds"},
+			"reaction_counts": map[string]int{"like": 3, "comment": 1},
+			"latest_reactions": map[string]any{
+				"like": []map[string]any{{
+					"id":          "r1",
+					"kind":        "like",
+					"activity_id": fmt.Sprintf("a0b1c2d3-%d", i),
+					"user_id":     "alice",
+					"user":        map[string]any{"id": "alice", "name": "Alice"},
+					"created_at":  "2024-01-02T15:04:05.999999Z",
+					"updated_at":  "2024-01-02T15:04:05.999999Z",
+				}},
+			},
+		}
+	}
+	body, err := json.Marshal(map[string]any{"duration": "12ms", "next": "", "results": results})
+	require.NoError(b, err)
+	return body
+}
+
+func BenchmarkEnrichedFeedDecode(b *testing.B) {
+	ctx := context.Background()
+	body := benchmarkEnrichedFeedBody(b, 100)
+	header := http.Header{}
+	header.Set(HeaderRateLimit, "100")
+
+	b.Run("legacy", func(b *testing.B) {
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			data, err := legacyRemarshal(body, NewRate(header))
+			if err != nil {
+				b.Fatal(err)
+			}
+			var resp legacyEnrichedFlatFeedResponse
+			if err := json.Unmarshal(data, &resp); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("streaming", func(b *testing.B) {
+		c := &Client{requester: bodyRequester{header: header, body: body}}
+		ep := endpoint{url: &url.URL{}, query: url.Values{}}
+		b.ReportAllocs()
+		for i := 0; i < b.N; i++ {
+			var resp EnrichedFlatFeedResponse
+			if err := c.request(ctx, http.MethodGet, ep, nil, &resp, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+type deadlineRecorder struct {
+	deadline time.Time
+	ok       bool
+}
+
+func (r *deadlineRecorder) Do(req *http.Request) (*http.Response, error) {
+	r.deadline, r.ok = req.Context().Deadline()
+	return &http.Response{StatusCode: http.StatusOK, Body: io.NopCloser(strings.NewReader("{}"))}, nil
+}
+
+func TestClientTimeout(t *testing.T) {
+	client, err := New("key", "secret", WithTimeout(2*time.Second))
+	require.NoError(t, err)
+	assert.Zero(t, client.requester.(*http.Client).Timeout)
+	recorder := &deadlineRecorder{}
+	client.requester = recorder
+
+	_, err = client.Users().Get(context.Background(), "u1")
+	require.NoError(t, err)
+	require.True(t, recorder.ok)
+	assert.WithinDuration(t, time.Now().Add(2*time.Second), recorder.deadline, time.Second)
+
+	ctx := WithCallOptions(context.Background(), WithCallTimeout(time.Minute))
+	_, err = client.Users().Get(ctx, "u1")
+	require.NoError(t, err)
+	require.True(t, recorder.ok)
+	assert.WithinDuration(t, time.Now().Add(time.Minute), recorder.deadline, time.Second)
+
+	custom, err := New("key", "secret", WithHTTPRequester(recorder))
+	require.NoError(t, err)
+	_, err = custom.Users().Get(context.Background(), "u1")
+	require.NoError(t, err)
+	assert.False(t, recorder.ok)
+}
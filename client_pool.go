@@ -0,0 +1,301 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+// Credentials are the settings used by a ClientPool to build the Client of a
+// tenant.
+type Credentials struct {
+	Key     string
+	Secret  string
+	Region  string
+	Version string
+	Addr    string
+}
+
+// CredentialsProvider resolves the Credentials of a tenant, for example from a
+// database or a secrets manager.
+type CredentialsProvider interface {
+	Credentials(ctx context.Context, tenant string) (Credentials, error)
+}
+
+// CredentialsProviderFunc is a function implementing CredentialsProvider.
+type CredentialsProviderFunc func(ctx context.Context, tenant string) (Credentials, error)
+
+// Credentials calls f(ctx, tenant).
+func (f CredentialsProviderFunc) Credentials(ctx context.Context, tenant string) (Credentials, error) {
+	return f(ctx, tenant)
+}
+
+// ClientPool lazily builds and caches a Client per tenant, each one with its
+// own API key, secret and region. All the clients share the same Requester,
+// and thus the same HTTP transport and connection pool.
+type ClientPool struct {
+	provider  CredentialsProvider
+	requester Requester
+	// defaultRequester tells whether requester was built by the pool, in
+	// which case its clients enforce the timeout.
+	defaultRequester bool
+	timeout          time.Duration
+	clientOptions    []ClientOption
+	credentialsTTL   time.Duration
+	idleTimeout      time.Duration
+	now              func() time.Time
+
+	mu        sync.Mutex
+	clients   map[string]*pooledClient
+	loads     map[string]*poolLoad
+	lastSweep time.Time
+}
+
+// poolLoad is an in-flight load of the credentials of a tenant, shared by
+// the concurrent calls needing it.
+type poolLoad struct {
+	done   chan struct{}
+	client *Client
+	err    error
+}
+
+type pooledClient struct {
+	client      *Client
+	credentials Credentials
+	loadedAt    time.Time
+	lastUsed    time.Time
+}
+
+// ClientPoolOption is a function used for adding specific configuration
+// options to a ClientPool.
+type ClientPoolOption func(*ClientPool)
+
+// WithPoolHTTPRequester sets the Requester shared by all the clients of the
+// pool. By default, a new one is built using the pool timeout.
+func WithPoolHTTPRequester(requester Requester) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.requester = requester
+	}
+}
+
+// WithPoolTimeout sets the timeout of the API calls of the clients, unless a
+// custom Requester is set with WithPoolHTTPRequester.
+func WithPoolTimeout(timeout time.Duration) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.timeout = timeout
+	}
+}
+
+// WithPoolClientOptions sets additional ClientOptions applied to every client
+// built by the pool.
+func WithPoolClientOptions(opts ...ClientOption) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.clientOptions = append(p.clientOptions, opts...)
+	}
+}
+
+// WithPoolCredentialsTTL makes the pool reload the credentials of a tenant
+// once they are older than ttl, rebuilding its Client if they changed.
+func WithPoolCredentialsTTL(ttl time.Duration) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.credentialsTTL = ttl
+	}
+}
+
+// WithPoolIdleTimeout makes the pool evict the clients which haven't been used
+// for longer than timeout.
+func WithPoolIdleTimeout(timeout time.Duration) ClientPoolOption {
+	return func(p *ClientPool) {
+		p.idleTimeout = timeout
+	}
+}
+
+// NewClientPool builds a new ClientPool resolving tenants with the given
+// CredentialsProvider.
+func NewClientPool(provider CredentialsProvider, opts ...ClientPoolOption) (*ClientPool, error) {
+	if provider == nil {
+		return nil, errors.New("missing credentials provider")
+	}
+	p := &ClientPool{
+		provider: provider,
+		timeout:  time.Second * 6,
+		now:      time.Now,
+		clients:  make(map[string]*pooledClient),
+		loads:    make(map[string]*poolLoad),
+	}
+	for _, opt := range opts {
+		opt(p)
+	}
+	if p.requester == nil {
+		p.requester = newRequester()
+		p.defaultRequester = true
+	}
+	return p, nil
+}
+
+// Client returns the Client of the given tenant, building it when needed.
+// Concurrent calls for the same tenant share a single credentials lookup. If
+// reloading expired credentials fails, the cached Client keeps being served.
+func (p *ClientPool) Client(ctx context.Context, tenant string) (*Client, error) {
+	now := p.now()
+
+	p.mu.Lock()
+	p.sweep(now)
+	pc, ok := p.clients[tenant]
+	if ok && (p.credentialsTTL <= 0 || now.Sub(pc.loadedAt) < p.credentialsTTL) {
+		pc.lastUsed = now
+		p.mu.Unlock()
+		return pc.client, nil
+	}
+	p.mu.Unlock()
+
+	client, err := p.loadShared(ctx, tenant)
+	if err != nil && ok {
+		p.mu.Lock()
+		pc.lastUsed = now
+		p.mu.Unlock()
+		return pc.client, nil
+	}
+	return client, err
+}
+
+// Reload fetches again the credentials of the given tenant, rebuilding its
+// Client if they changed.
+func (p *ClientPool) Reload(ctx context.Context, tenant string) error {
+	_, err := p.loadShared(ctx, tenant)
+	return err
+}
+
+// ReloadAll reloads the credentials of all the cached tenants, returning the
+// first error encountered, if any.
+func (p *ClientPool) ReloadAll(ctx context.Context) error {
+	var firstErr error
+	for _, tenant := range p.Tenants() {
+		if err := p.Reload(ctx, tenant); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
+}
+
+// Evict removes the Client of the given tenant from the pool.
+func (p *ClientPool) Evict(tenant string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.clients, tenant)
+}
+
+// EvictIdle removes the clients which haven't been used for longer than the
+// pool idle timeout, returning how many were evicted.
+func (p *ClientPool) EvictIdle() int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.evictIdle(p.now())
+}
+
+// Tenants returns the tenants currently cached in the pool.
+func (p *ClientPool) Tenants() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	tenants := make([]string, 0, len(p.clients))
+	for tenant := range p.clients {
+		tenants = append(tenants, tenant)
+	}
+	return tenants
+}
+
+// loadShared loads the credentials of the tenant, or waits for the load
+// already in flight, if any. The load runs detached from the context of the
+// caller starting it, bounded by the pool timeout, so that canceling one
+// caller doesn't fail the others waiting for it.
+func (p *ClientPool) loadShared(ctx context.Context, tenant string) (*Client, error) {
+	p.mu.Lock()
+	l, ok := p.loads[tenant]
+	if !ok {
+		l = &poolLoad{done: make(chan struct{})}
+		p.loads[tenant] = l
+		go p.runLoad(context.WithoutCancel(ctx), tenant, l)
+	}
+	p.mu.Unlock()
+
+	select {
+	case <-l.done:
+		return l.client, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// runLoad performs the shared load l of the credentials of the tenant.
+func (p *ClientPool) runLoad(ctx context.Context, tenant string, l *poolLoad) {
+	if p.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, p.timeout)
+		defer cancel()
+	}
+	l.client, l.err = p.load(ctx, tenant)
+	p.mu.Lock()
+	delete(p.loads, tenant)
+	p.mu.Unlock()
+	close(l.done)
+}
+
+func (p *ClientPool) load(ctx context.Context, tenant string) (*Client, error) {
+	creds, err := p.provider.Credentials(ctx, tenant)
+	if err != nil {
+		return nil, err
+	}
+	now := p.now()
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if pc, ok := p.clients[tenant]; ok && pc.credentials == creds {
+		pc.loadedAt = now
+		pc.lastUsed = now
+		return pc.client, nil
+	}
+
+	opts := append([]ClientOption{
+		WithAPIRegion(creds.Region),
+		WithAPIVersion(creds.Version),
+		WithAPIAddr(creds.Addr),
+		WithTimeout(p.timeout),
+	}, p.clientOptions...)
+	opts = append(opts, WithHTTPRequester(p.requester))
+	client, err := New(creds.Key, creds.Secret, opts...)
+	if err != nil {
+		return nil, err
+	}
+	client.enforceTimeout = p.defaultRequester
+	p.clients[tenant] = &pooledClient{
+		client:      client,
+		credentials: creds,
+		loadedAt:    now,
+		lastUsed:    now,
+	}
+	return client, nil
+}
+
+// sweep evicts idle clients at most once per idle timeout period.
+func (p *ClientPool) sweep(now time.Time) {
+	if p.idleTimeout <= 0 || now.Sub(p.lastSweep) < p.idleTimeout {
+		return
+	}
+	p.evictIdle(now)
+}
+
+func (p *ClientPool) evictIdle(now time.Time) int {
+	p.lastSweep = now
+	if p.idleTimeout <= 0 {
+		return 0
+	}
+	var n int
+	for tenant, pc := range p.clients {
+		if now.Sub(pc.lastUsed) > p.idleTimeout {
+			delete(p.clients, tenant)
+			n++
+		}
+	}
+	return n
+}
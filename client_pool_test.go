@@ -0,0 +1,234 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+type tenantsProvider struct {
+	mu          sync.Mutex
+	calls       int
+	credentials map[string]stream.Credentials
+}
+
+func (p *tenantsProvider) Credentials(_ context.Context, tenant string) (stream.Credentials, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.calls++
+	creds, ok := p.credentials[tenant]
+	if !ok {
+		return stream.Credentials{}, errors.New("unknown tenant")
+	}
+	return creds, nil
+}
+
+func (p *tenantsProvider) set(tenant string, creds stream.Credentials) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.credentials[tenant] = creds
+}
+
+func TestClientPool(t *testing.T) {
+	ctx := context.Background()
+	provider := &tenantsProvider{credentials: map[string]stream.Credentials{
+		"acme":   {Key: "acme-key", Secret: "acme-secret", Region: "us-east"},
+		"globex": {Key: "globex-key", Secret: "globex-secret", Region: "singapore"},
+	}}
+	requester := &mockRequester{}
+	pool, err := stream.NewClientPool(provider, stream.WithPoolHTTPRequester(requester))
+	require.NoError(t, err)
+
+	acme, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	again, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	assert.Same(t, acme, again)
+	assert.Equal(t, 1, provider.calls)
+
+	_, err = acme.Users().Get(ctx, "john")
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://us-east-api.stream-io-api.com/api/v1.0/user/john/?api_key=acme-key", "")
+
+	globex, err := pool.Client(ctx, "globex")
+	require.NoError(t, err)
+	_, err = globex.Users().Get(ctx, "john")
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://singapore-api.stream-io-api.com/api/v1.0/user/john/?api_key=globex-key", "")
+
+	tenants := pool.Tenants()
+	sort.Strings(tenants)
+	assert.Equal(t, []string{"acme", "globex"}, tenants)
+
+	_, err = pool.Client(ctx, "initech")
+	require.Error(t, err)
+
+	provider.set("acme", stream.Credentials{Key: "acme-key", Secret: "acme-secret", Region: "us-east"})
+	require.NoError(t, pool.Reload(ctx, "acme"))
+	again, err = pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	assert.Same(t, acme, again)
+
+	provider.set("acme", stream.Credentials{Key: "acme-key-2", Secret: "acme-secret-2", Region: "eu-west"})
+	require.NoError(t, pool.ReloadAll(ctx))
+	reloaded, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	assert.NotSame(t, acme, reloaded)
+	_, err = reloaded.Users().Get(ctx, "john")
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://eu-west-api.stream-io-api.com/api/v1.0/user/john/?api_key=acme-key-2", "")
+
+	pool.Evict("globex")
+	assert.Equal(t, []string{"acme"}, pool.Tenants())
+}
+
+func TestClientPoolCredentialsTTL(t *testing.T) {
+	ctx := context.Background()
+	provider := &tenantsProvider{credentials: map[string]stream.Credentials{
+		"acme": {Key: "acme-key", Secret: "acme-secret"},
+	}}
+	pool, err := stream.NewClientPool(provider, stream.WithPoolHTTPRequester(&mockRequester{}), stream.WithPoolCredentialsTTL(time.Nanosecond))
+	require.NoError(t, err)
+
+	acme, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	time.Sleep(time.Millisecond)
+	again, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	assert.Same(t, acme, again)
+	assert.Equal(t, 2, provider.calls)
+}
+
+func TestClientPoolIdleTimeout(t *testing.T) {
+	ctx := context.Background()
+	provider := &tenantsProvider{credentials: map[string]stream.Credentials{
+		"acme": {Key: "acme-key", Secret: "acme-secret"},
+	}}
+	pool, err := stream.NewClientPool(provider, stream.WithPoolHTTPRequester(&mockRequester{}), stream.WithPoolIdleTimeout(time.Millisecond))
+	require.NoError(t, err)
+
+	_, err = pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	time.Sleep(5 * time.Millisecond)
+	assert.Equal(t, 1, pool.EvictIdle())
+	assert.Empty(t, pool.Tenants())
+
+	_, err = stream.NewClientPool(nil)
+	require.Error(t, err)
+}
+
+type blockingProvider struct {
+	tenantsProvider
+	release chan struct{}
+	fail    bool
+}
+
+func (p *blockingProvider) Credentials(ctx context.Context, tenant string) (stream.Credentials, error) {
+	select {
+	case <-p.release:
+	case <-ctx.Done():
+		return stream.Credentials{}, ctx.Err()
+	}
+	if p.fail {
+		return stream.Credentials{}, errors.New("secrets manager down")
+	}
+	return p.tenantsProvider.Credentials(ctx, tenant)
+}
+
+func TestClientPoolSharedLoads(t *testing.T) {
+	ctx := context.Background()
+	provider := &blockingProvider{
+		tenantsProvider: tenantsProvider{credentials: map[string]stream.Credentials{
+			"acme": {Key: "acme-key", Secret: "acme-secret"},
+		}},
+		release: make(chan struct{}),
+	}
+	pool, err := stream.NewClientPool(provider, stream.WithPoolHTTPRequester(&mockRequester{}))
+	require.NoError(t, err)
+
+	clients := make([]*stream.Client, 10)
+	var wg sync.WaitGroup
+	for i := range clients {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			c, err := pool.Client(ctx, "acme")
+			assert.NoError(t, err)
+			clients[i] = c
+		}(i)
+	}
+	time.Sleep(10 * time.Millisecond)
+	close(provider.release)
+	wg.Wait()
+	assert.Equal(t, 1, provider.calls)
+	for _, c := range clients {
+		assert.Same(t, clients[0], c)
+	}
+}
+
+func TestClientPoolSharedLoadCanceled(t *testing.T) {
+	provider := &blockingProvider{
+		tenantsProvider: tenantsProvider{credentials: map[string]stream.Credentials{
+			"acme": {Key: "acme-key", Secret: "acme-secret"},
+		}},
+		release: make(chan struct{}),
+	}
+	pool, err := stream.NewClientPool(provider, stream.WithPoolHTTPRequester(&mockRequester{}))
+	require.NoError(t, err)
+
+	// the first caller gives up while the load it started is in flight
+	ctx, cancel := context.WithCancel(context.Background())
+	first := make(chan error)
+	go func() {
+		_, err := pool.Client(ctx, "acme")
+		first <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	second := make(chan *stream.Client)
+	go func() {
+		c, err := pool.Client(context.Background(), "acme")
+		assert.NoError(t, err)
+		second <- c
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	require.ErrorIs(t, <-first, context.Canceled)
+
+	close(provider.release)
+	assert.NotNil(t, <-second)
+	assert.Equal(t, 1, provider.calls)
+}
+
+func TestClientPoolStaleFallback(t *testing.T) {
+	ctx := context.Background()
+	provider := &blockingProvider{
+		tenantsProvider: tenantsProvider{credentials: map[string]stream.Credentials{
+			"acme": {Key: "acme-key", Secret: "acme-secret"},
+		}},
+		release: make(chan struct{}),
+	}
+	close(provider.release)
+	pool, err := stream.NewClientPool(provider, stream.WithPoolHTTPRequester(&mockRequester{}), stream.WithPoolCredentialsTTL(time.Nanosecond))
+	require.NoError(t, err)
+
+	acme, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	provider.fail = true
+	time.Sleep(time.Millisecond)
+	stale, err := pool.Client(ctx, "acme")
+	require.NoError(t, err)
+	assert.Same(t, acme, stale)
+
+	require.Error(t, pool.Reload(ctx, "acme"))
+	_, err = pool.Client(ctx, "globex")
+	require.Error(t, err)
+}
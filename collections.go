@@ -2,9 +2,9 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 )
 
@@ -24,7 +24,11 @@ func (c *CollectionsClient) Upsert(ctx context.Context, collection string, objec
 			collection: objects,
 		},
 	}
-	return decode(c.client.post(ctx, endpoint, data, c.client.authenticator.collectionsAuth))
+	var result BaseResponse
+	if err := c.client.post(ctx, endpoint, data, &result, c.client.authenticator.collectionsAuth); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // Select returns a list of CollectionObjects for the given collection name
@@ -39,12 +43,8 @@ func (c *CollectionsClient) Select(ctx context.Context, collection string, ids .
 	}
 	endpoint := c.client.makeEndpoint("collections/")
 	endpoint.addQueryParam(makeRequestOption("foreign_ids", strings.Join(foreignIDs, ",")))
-	resp, err := c.client.get(ctx, endpoint, nil, c.client.authenticator.collectionsAuth)
-	if err != nil {
-		return nil, err
-	}
 	var result getCollectionResponseWrap
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := c.client.get(ctx, endpoint, nil, &result, c.client.authenticator.collectionsAuth); err != nil {
 		return nil, err
 	}
 	return &GetCollectionResponse{
@@ -61,15 +61,16 @@ func (c *CollectionsClient) DeleteMany(ctx context.Context, collection string, i
 	endpoint := c.client.makeEndpoint("collections/")
 	endpoint.addQueryParam(makeRequestOption("collection_name", collection))
 	endpoint.addQueryParam(makeRequestOption("ids", strings.Join(ids, ",")))
-	return decode(c.client.delete(ctx, endpoint, nil, c.client.authenticator.collectionsAuth))
-}
-
-func (c *CollectionsClient) decodeObject(resp []byte, err error) (*CollectionObjectResponse, error) {
-	if err != nil {
+	var result BaseResponse
+	if err := c.client.delete(ctx, endpoint, nil, &result, c.client.authenticator.collectionsAuth); err != nil {
 		return nil, err
 	}
+	return &result, nil
+}
+
+func (c *CollectionsClient) doObject(ctx context.Context, method string, endpoint endpoint, data any) (*CollectionObjectResponse, error) {
 	var result CollectionObjectResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := c.client.request(ctx, method, endpoint, data, &result, c.client.authenticator.collectionsAuth); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -91,7 +92,7 @@ func (c *CollectionsClient) Add(ctx context.Context, collection string, object C
 	req.ID = object.ID
 	req.Data = object.Data
 
-	return c.decodeObject(c.client.post(ctx, endpoint, req, c.client.authenticator.collectionsAuth))
+	return c.doObject(ctx, http.MethodPost, endpoint, req)
 }
 
 // Get retrieves a collection object having the given ID.
@@ -101,7 +102,7 @@ func (c *CollectionsClient) Get(ctx context.Context, collection, id string) (*Co
 	}
 	endpoint := c.client.makeEndpoint("collections/%s/%s/", collection, id)
 
-	return c.decodeObject(c.client.get(ctx, endpoint, nil, c.client.authenticator.collectionsAuth))
+	return c.doObject(ctx, http.MethodGet, endpoint, nil)
 }
 
 // Update updates the given collection object's data.
@@ -114,7 +115,7 @@ func (c *CollectionsClient) Update(ctx context.Context, collection, id string, d
 		"data": data,
 	}
 
-	return c.decodeObject(c.client.put(ctx, endpoint, reqData, c.client.authenticator.collectionsAuth))
+	return c.doObject(ctx, http.MethodPut, endpoint, reqData)
 }
 
 // Delete removes from a collection the object having the given ID.
@@ -124,7 +125,11 @@ func (c *CollectionsClient) Delete(ctx context.Context, collection, id string) (
 	}
 	endpoint := c.client.makeEndpoint("collections/%s/%s/", collection, id)
 
-	return decode(c.client.delete(ctx, endpoint, nil, c.client.authenticator.collectionsAuth))
+	var result BaseResponse
+	if err := c.client.delete(ctx, endpoint, nil, &result, c.client.authenticator.collectionsAuth); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // CreateReference returns a new reference string in the form SO:<collection>:<id>.
@@ -0,0 +1,54 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+const defaultCompressionThreshold = 1024
+
+// compression holds the gzip settings of a Client.
+type compression struct {
+	threshold int
+}
+
+var gzipWriters = sync.Pool{
+	New: func() any {
+		return gzip.NewWriter(io.Discard)
+	},
+}
+
+func gzipPayload(payload []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzipWriters.Get().(*gzip.Writer)
+	defer gzipWriters.Put(w)
+	w.Reset(&buf)
+	if _, err := w.Write(payload); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decompressBody returns a reader for the response body, transparently
+// decompressing it when the API replied with a gzip content encoding. Empty
+// bodies are returned as is, since they aren't valid gzip streams. The
+// returned reader must be closed.
+func decompressBody(resp *http.Response) (io.ReadCloser, error) {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return io.NopCloser(resp.Body), nil
+	}
+	br := bufio.NewReader(resp.Body)
+	if _, err := br.Peek(1); errors.Is(err, io.EOF) {
+		return io.NopCloser(br), nil
+	}
+	return gzip.NewReader(br)
+}
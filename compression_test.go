@@ -0,0 +1,102 @@
+package stream_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// gzipResponse is a gzip-encoded response with the given body.
+func gzipResponse(code int, body string) (*http.Response, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write([]byte(body)); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return &http.Response{
+		StatusCode: code,
+		Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+		Body:       io.NopCloser(&buf),
+	}, nil
+}
+
+func TestCompression(t *testing.T) {
+	ctx := context.Background()
+	resp := `{"activities":[{"actor":"bob","verb":"like","object":"cake"}]}`
+	client, requester := newRecordingClient(t, func(recordedRequest, int) (*http.Response, error) {
+		return gzipResponse(http.StatusOK, resp)
+	}, stream.WithCompression(64))
+	flat, err := newFlatFeedWithUserID(client, "123")
+	require.NoError(t, err)
+
+	activities := []stream.Activity{
+		{Actor: "bob", Verb: "like", Object: "cake"},
+		{Actor: "alice", Verb: "like", Object: "pie"},
+	}
+	added, err := flat.AddActivities(ctx, activities...)
+	require.NoError(t, err)
+	assert.Equal(t, activities[:1], added.Activities)
+	req := requester.recorded()[0]
+	assert.Equal(t, "gzip", req.header.Get("Content-Encoding"))
+	assert.Equal(t, "gzip", req.header.Get("Accept-Encoding"))
+	r, err := gzip.NewReader(bytes.NewReader(req.body))
+	require.NoError(t, err)
+	body, err := io.ReadAll(r)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"activities":[{"actor":"bob","object":"cake","verb":"like"},{"actor":"alice","object":"pie","verb":"like"}]}`, string(body))
+
+	resp = `{}`
+	_, err = flat.AddActivity(ctx, activities[0])
+	require.NoError(t, err)
+	req = requester.recorded()[1]
+	assert.Empty(t, req.header.Get("Content-Encoding"))
+	assert.Equal(t, "gzip", req.header.Get("Accept-Encoding"))
+	assert.Equal(t, "POST /api/v1.0/feed/flat/123/", req.String())
+	assert.JSONEq(t, `{"actor":"bob","object":"cake","verb":"like"}`, string(req.body))
+}
+
+func TestCompressedResponseWithoutCompression(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newRecordingClient(t, func(recordedRequest, int) (*http.Response, error) {
+		return gzipResponse(http.StatusOK, `{"results":[{"actor":"bob","verb":"like","object":"cake"}]}`)
+	})
+	flat, err := newFlatFeedWithUserID(client, "123")
+	require.NoError(t, err)
+
+	resp, err := flat.GetActivities(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []stream.Activity{{Actor: "bob", Verb: "like", Object: "cake"}}, resp.Results)
+	assert.Empty(t, requester.recorded()[0].header.Get("Accept-Encoding"))
+}
+
+func TestCompressedEmptyResponses(t *testing.T) {
+	ctx := context.Background()
+	status := http.StatusNoContent
+	client, err := stream.New("key", "secret", stream.WithCompression(64), stream.WithHTTPRequester(requesterFunc(func(*http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: status,
+			Header:     http.Header{"Content-Encoding": []string{"gzip"}},
+			Body:       io.NopCloser(bytes.NewReader(nil)),
+		}, nil
+	})))
+	require.NoError(t, err)
+
+	require.NoError(t, client.Reactions().SoftDelete(ctx, "r1"))
+
+	status = http.StatusServiceUnavailable
+	err = client.Reactions().SoftDelete(ctx, "r1")
+	var apiErr stream.APIError
+	require.ErrorAs(t, err, &apiErr)
+	assert.Equal(t, http.StatusServiceUnavailable, apiErr.StatusCode)
+}
@@ -0,0 +1,274 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultAutoFlagReporter is the user flagging the content on behalf of the
+// content filters, unless set with WithAutoFlag.
+const DefaultAutoFlagReporter = "content-filter"
+
+// VerdictAction is the decision of a content filter.
+type VerdictAction int
+
+const (
+	// VerdictAllow lets the content through.
+	VerdictAllow VerdictAction = iota
+	// VerdictReject prevents the write, which fails with a
+	// ContentRejectedError.
+	VerdictReject
+	// VerdictFlag lets the content through and flags it for review once
+	// written.
+	VerdictFlag
+)
+
+// Verdict is the outcome of a content filter.
+type Verdict struct {
+	Action VerdictAction
+	// Reason is the reason of the rejection or flag.
+	Reason string
+	// FlagOptions add data to the flag, such as WithFlagCategory.
+	FlagOptions []FlagOption
+}
+
+// AllowVerdict lets the content through.
+func AllowVerdict() Verdict {
+	return Verdict{Action: VerdictAllow}
+}
+
+// RejectVerdict prevents the write for the given reason.
+func RejectVerdict(reason string) Verdict {
+	return Verdict{Action: VerdictReject, Reason: reason}
+}
+
+// FlagVerdict lets the content through and flags it for the given reason.
+func FlagVerdict(reason string, opts ...FlagOption) Verdict {
+	return Verdict{Action: VerdictFlag, Reason: reason, FlagOptions: opts}
+}
+
+// ActivityFilter checks an activity before it's added or updated.
+type ActivityFilter func(context.Context, Activity) (Verdict, error)
+
+// ReactionFilter checks a reaction before it's added or updated. For
+// updates, only the ID, Data and TargetFeeds of the reaction are set.
+type ReactionFilter func(context.Context, AddReactionRequestObject) (Verdict, error)
+
+// ContentRejectedError is returned by writes rejected by a content filter.
+type ContentRejectedError struct {
+	// Index is the position of the rejected item among the written ones.
+	Index  int
+	Reason string
+}
+
+func (e ContentRejectedError) Error() string {
+	return fmt.Sprintf("content %d rejected: %s", e.Index, e.Reason)
+}
+
+type contentFilters struct {
+	activities  []ActivityFilter
+	reactions   []ReactionFilter
+	reporter    string
+	onFlagError func(error)
+}
+
+// WithActivityFilters adds filters consulted, in order, by the calls adding
+// or updating activities: AddActivity, AddActivities, AddToMany,
+// UpdateActivities, the partial updates and their batched variants. Partial
+// updates are checked on the fields they set. The first rejection stops the
+// write of all the activities of the call.
+//
+// Flagged activities are flagged by ID, looked up by foreign ID and time for
+// the calls not returning it. Flagging an activity having neither fails and is
+// reported to the function set with WithAutoFlag.
+func WithActivityFilters(filters ...ActivityFilter) ClientOption {
+	return func(c *Client) {
+		c.filters.activities = append(c.filters.activities, filters...)
+	}
+}
+
+// WithReactionFilters adds filters consulted, in order, by the calls adding
+// or updating reactions.
+func WithReactionFilters(filters ...ReactionFilter) ClientOption {
+	return func(c *Client) {
+		c.filters.reactions = append(c.filters.reactions, filters...)
+	}
+}
+
+// WithAutoFlag sets the user flagging the content on behalf of the content
+// filters, and the function called when flagging fails. Since the content
+// was written already, such failures don't fail the write.
+func WithAutoFlag(reporterID string, onError func(error)) ClientOption {
+	return func(c *Client) {
+		c.filters.reporter = reporterID
+		c.filters.onFlagError = onError
+	}
+}
+
+// mergeVerdict merges the verdict of a filter into the result of the
+// previous ones, keeping the first flag, and tells whether the content is
+// rejected.
+func mergeVerdict(result *Verdict, v Verdict) bool {
+	switch {
+	case v.Action == VerdictReject:
+		*result = v
+		return true
+	case v.Action == VerdictFlag && result.Action == VerdictAllow:
+		*result = v
+	}
+	return false
+}
+
+func (c *Client) activityVerdict(ctx context.Context, a Activity) (Verdict, error) {
+	result := AllowVerdict()
+	for _, filter := range c.filters.activities {
+		v, err := filter(ctx, a)
+		if err != nil {
+			return Verdict{}, fmt.Errorf("activity filter: %w", err)
+		}
+		if mergeVerdict(&result, v) {
+			break
+		}
+	}
+	return result, nil
+}
+
+// screenActivities runs the activity filters on the activities, returning the
+// verdicts of the ones to flag by index.
+func (c *Client) screenActivities(ctx context.Context, activities ...Activity) (map[int]Verdict, error) {
+	if len(c.filters.activities) == 0 {
+		return nil, nil
+	}
+	var flagged map[int]Verdict
+	for i, a := range activities {
+		v, err := c.activityVerdict(ctx, a)
+		if err != nil {
+			return nil, err
+		}
+		switch v.Action {
+		case VerdictReject:
+			return nil, ContentRejectedError{Index: i, Reason: v.Reason}
+		case VerdictFlag:
+			if flagged == nil {
+				flagged = make(map[int]Verdict)
+			}
+			flagged[i] = v
+		}
+	}
+	return flagged, nil
+}
+
+// flagActivities flags the written activities according to their verdicts,
+// looking up by foreign ID and time the ones lacking an ID.
+func (c *Client) flagActivities(ctx context.Context, activities []Activity, flagged map[int]Verdict) {
+	if len(flagged) == 0 {
+		return
+	}
+	var lookup []ForeignIDTimePair
+	for i := range flagged {
+		if activities[i].ID == "" && activities[i].ForeignID != "" && !activities[i].Time.IsZero() {
+			lookup = append(lookup, NewForeignIDTimePair(activities[i].ForeignID, activities[i].Time))
+		}
+	}
+	ids := make(map[string]string)
+	if len(lookup) > 0 {
+		resp, err := c.GetActivitiesByForeignID(ctx, lookup...)
+		if err != nil {
+			c.autoFlagFailed(fmt.Errorf("cannot look up flagged activities: %w", err))
+			return
+		}
+		for _, a := range resp.Results {
+			ids[foreignIDTimeKey(a.ForeignID, a.Time)] = a.ID
+		}
+	}
+	moderation := c.Moderation()
+	for i, v := range flagged {
+		id := activities[i].ID
+		if id == "" {
+			id = ids[foreignIDTimeKey(activities[i].ForeignID, activities[i].Time)]
+		}
+		if id == "" {
+			c.autoFlagFailed(fmt.Errorf("cannot flag activity %d: unknown ID", i))
+			continue
+		}
+		if err := moderation.FlagActivity(ctx, c.autoFlagReporter(), id, v.Reason, v.FlagOptions...); err != nil {
+			c.autoFlagFailed(fmt.Errorf("cannot flag activity %s: %w", id, err))
+		}
+	}
+}
+
+// changesetActivity returns the fields set by a partial update as an
+// activity, identified as the updated one, for the filters to check them.
+func changesetActivity(r UpdateActivityRequest) Activity {
+	var a Activity
+	if b, err := json.Marshal(r.Set); err != nil || json.Unmarshal(b, &a) != nil {
+		// fields of unexpected types are left to the filters as they are
+		a = Activity{Extra: r.Set}
+	}
+	a.ID, a.ForeignID, a.Time = "", "", Time{}
+	if r.ID != nil {
+		a.ID = *r.ID
+	}
+	if r.ForeignID != nil && r.Time != nil {
+		a.ForeignID, a.Time = *r.ForeignID, *r.Time
+	}
+	return a
+}
+
+// screenChangesets runs the activity filters on the fields set by the partial
+// updates, returning them as activities along with the verdicts of the ones to
+// flag by index.
+func (c *Client) screenChangesets(ctx context.Context, changesets ...UpdateActivityRequest) ([]Activity, map[int]Verdict, error) {
+	if len(c.filters.activities) == 0 {
+		return nil, nil, nil
+	}
+	activities := make([]Activity, len(changesets))
+	for i, r := range changesets {
+		activities[i] = changesetActivity(r)
+	}
+	flagged, err := c.screenActivities(ctx, activities...)
+	return activities, flagged, err
+}
+
+// screenReaction runs the reaction filters on the reaction, returning the
+// verdict.
+func (c *Client) screenReaction(ctx context.Context, r AddReactionRequestObject) (Verdict, error) {
+	if len(c.filters.reactions) == 0 {
+		return AllowVerdict(), nil
+	}
+	result := AllowVerdict()
+	for _, filter := range c.filters.reactions {
+		v, err := filter(ctx, r)
+		if err != nil {
+			return Verdict{}, fmt.Errorf("reaction filter: %w", err)
+		}
+		if mergeVerdict(&result, v) {
+			return Verdict{}, ContentRejectedError{Reason: result.Reason}
+		}
+	}
+	return result, nil
+}
+
+// flagReaction flags the written reaction if its verdict says so.
+func (c *Client) flagReaction(ctx context.Context, reactionID string, v Verdict) {
+	if v.Action != VerdictFlag {
+		return
+	}
+	if err := c.Moderation().FlagReaction(ctx, c.autoFlagReporter(), reactionID, v.Reason, v.FlagOptions...); err != nil {
+		c.autoFlagFailed(fmt.Errorf("cannot flag reaction %s: %w", reactionID, err))
+	}
+}
+
+func (c *Client) autoFlagReporter() string {
+	if c.filters.reporter == "" {
+		return DefaultAutoFlagReporter
+	}
+	return c.filters.reporter
+}
+
+func (c *Client) autoFlagFailed(err error) {
+	if c.filters.onFlagError != nil {
+		c.filters.onFlagError(err)
+	}
+}
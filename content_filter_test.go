@@ -0,0 +1,263 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// newModeratedClient returns a client whose requests are recorded, writes
+// being answered with IDs and flags with an error while failFlags is set.
+func newModeratedClient(t *testing.T, failFlags *bool, opts ...stream.ClientOption) (*stream.Client, *recordingRequester) {
+	return newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		var body map[string]any
+		_ = r.decode(&body)
+		switch {
+		case strings.HasSuffix(r.path, "/moderation/flag/"):
+			if failFlags != nil && *failFlags {
+				return errorResponse(http.StatusInternalServerError, "down"), nil
+			}
+			return jsonResponse(http.StatusCreated, `{}`), nil
+		case strings.HasSuffix(r.path, "/reaction/"):
+			body["id"] = "r-new"
+		case strings.HasSuffix(r.path, "/activities/") && r.method == http.MethodGet:
+			body = map[string]any{"results": []map[string]any{
+				{"id": "looked-up", "foreign_id": r.query.Get("foreign_ids"), "time": r.query.Get("timestamps")},
+			}}
+		case strings.Contains(r.path, "/feed/user/"):
+			if acts, ok := body["activities"].([]any); ok {
+				for i, a := range acts {
+					a.(map[string]any)["id"] = fmt.Sprint("a-", i)
+				}
+			} else {
+				body["id"] = "a-new"
+			}
+		default:
+			return jsonResponse(http.StatusOK, `{}`), nil
+		}
+		raw, _ := json.Marshal(body)
+		return jsonResponse(http.StatusCreated, string(raw)), nil
+	}, opts...)
+}
+
+// sentFlags returns the bodies of the flags sent so far.
+func sentFlags(t *testing.T, requester *recordingRequester) []map[string]any {
+	var flags []map[string]any
+	for _, r := range requester.recorded() {
+		if strings.HasSuffix(r.path, "/moderation/flag/") {
+			var flag map[string]any
+			require.NoError(t, r.decode(&flag))
+			flags = append(flags, flag)
+		}
+	}
+	return flags
+}
+
+func spamFilter(_ context.Context, a stream.Activity) (stream.Verdict, error) {
+	text, _ := a.Extra["text"].(string)
+	switch {
+	case strings.Contains(text, "forbidden"):
+		return stream.RejectVerdict("forbidden words"), nil
+	case strings.Contains(text, "buy now"):
+		return stream.FlagVerdict("spam", stream.WithFlagCategory("ads")), nil
+	}
+	return stream.AllowVerdict(), nil
+}
+
+func TestActivityFilters(t *testing.T) {
+	var calls int
+	client, requester := newModeratedClient(t, nil,
+		stream.WithActivityFilters(
+			func(context.Context, stream.Activity) (stream.Verdict, error) {
+				calls++
+				return stream.FlagVerdict("first"), nil
+			},
+			spamFilter,
+		),
+		stream.WithAutoFlag("bot", nil),
+	)
+	feed, err := client.FlatFeed("user", "bob")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	resp, err := feed.AddActivity(ctx, stream.Activity{Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "hi"}})
+	require.NoError(t, err)
+	assert.Equal(t, "a-new", resp.ID)
+	flags := sentFlags(t, requester)
+	require.Len(t, flags, 1)
+	assert.Equal(t, map[string]any{
+		"user_id":     "bot",
+		"entity_type": stream.ModerationActivity,
+		"entity_id":   "a-new",
+		"reason":      "first",
+	}, flags[0])
+
+	requester.reset()
+	_, err = feed.AddActivities(ctx,
+		stream.Activity{Actor: "bob", Verb: "post", Object: "o"},
+		stream.Activity{Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "forbidden"}},
+	)
+	var rejected stream.ContentRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, stream.ContentRejectedError{Index: 1, Reason: "forbidden words"}, rejected)
+	assert.Empty(t, requester.recorded())
+	assert.Equal(t, 3, calls)
+}
+
+func TestActivityFiltersFlagAfterWrite(t *testing.T) {
+	client, requester := newModeratedClient(t, nil, stream.WithActivityFilters(spamFilter))
+	feed, err := client.FlatFeed("user", "bob")
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	_, err = feed.AddActivities(ctx,
+		stream.Activity{Actor: "bob", Verb: "post", Object: "o"},
+		stream.Activity{Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "buy now"}},
+	)
+	require.NoError(t, err)
+	flags := sentFlags(t, requester)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "a-1", flags[0]["entity_id"])
+	assert.Equal(t, stream.DefaultAutoFlagReporter, flags[0]["user_id"])
+	assert.Equal(t, map[string]any{"category": "ads"}, flags[0]["custom"])
+
+	requester.reset()
+	err = client.AddToMany(ctx, stream.Activity{Actor: "bob", Verb: "post", Object: "o", ForeignID: "post:1", Time: stream.Time{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}, Extra: map[string]any{"text": "buy now"}}, feed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /api/v1.0/feed/add_to_many/", "GET /api/v1.0/activities/", "POST /api/v1.0/moderation/flag/"}, requester.paths())
+	flags = sentFlags(t, requester)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "looked-up", flags[0]["entity_id"])
+
+	requester.reset()
+	err = client.AddToMany(ctx, stream.Activity{Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "buy now"}}, feed)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /api/v1.0/feed/add_to_many/"}, requester.paths())
+	assert.JSONEq(t, `{"activity":{"actor":"bob","verb":"post","object":"o","text":"buy now"},"feeds":["user:bob"]}`, string(requester.recorded()[0].body))
+
+	requester.reset()
+	_, err = client.UpdateActivities(ctx, stream.Activity{ID: "a1", Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "buy now"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /api/v1.0/activities/", "POST /api/v1.0/moderation/flag/"}, requester.paths())
+}
+
+func TestActivityFiltersPartialUpdates(t *testing.T) {
+	client, requester := newModeratedClient(t, nil, stream.WithActivityFilters(spamFilter))
+	ctx := context.Background()
+
+	_, err := client.PartialUpdateActivities(ctx,
+		stream.NewUpdateActivityRequestByID("a1", map[string]any{"text": "hi"}, nil),
+		stream.NewUpdateActivityRequestByID("a2", map[string]any{"text": "forbidden"}, nil),
+	)
+	var rejected stream.ContentRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, 1, rejected.Index)
+	assert.Empty(t, requester.recorded())
+
+	_, err = client.UpdateActivityByID(ctx, "a1", map[string]any{"text": "buy now"}, []string{"tags"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /api/v1.0/activity/", "POST /api/v1.0/moderation/flag/"}, requester.paths())
+	flags := sentFlags(t, requester)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "a1", flags[0]["entity_id"])
+
+	requester.reset()
+	_, err = client.PartialUpdateActivities(ctx,
+		stream.NewUpdateActivityRequestByForeignID("post:1", stream.Time{Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC)}, map[string]any{"text": "buy now", "actor": 42}, nil),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /api/v1.0/activity/", "GET /api/v1.0/activities/", "POST /api/v1.0/moderation/flag/"}, requester.paths())
+	flags = sentFlags(t, requester)
+	require.Len(t, flags, 1)
+	assert.Equal(t, "looked-up", flags[0]["entity_id"])
+}
+
+func TestActivityFilterErrors(t *testing.T) {
+	failFlags := true
+	var flagErrs []error
+	client, requester := newModeratedClient(t, &failFlags,
+		stream.WithActivityFilters(spamFilter),
+		stream.WithAutoFlag("bot", func(err error) { flagErrs = append(flagErrs, err) }),
+	)
+	feed, err := client.FlatFeed("user", "bob")
+	require.NoError(t, err)
+
+	_, err = feed.AddActivity(context.Background(), stream.Activity{Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "buy now"}})
+	require.NoError(t, err)
+	require.Len(t, flagErrs, 1)
+	assert.Contains(t, flagErrs[0].Error(), "cannot flag activity a-new")
+
+	flagErrs = nil
+	failFlags = false
+	requester.reset()
+	err = client.AddToMany(context.Background(), stream.Activity{Actor: "bob", Verb: "post", Object: "o", Extra: map[string]any{"text": "buy now"}}, feed)
+	require.NoError(t, err)
+	require.Len(t, flagErrs, 1)
+	assert.EqualError(t, flagErrs[0], "cannot flag activity 0: unknown ID")
+	assert.Empty(t, sentFlags(t, requester))
+
+	failing, err := stream.New("key", "secret",
+		stream.WithHTTPRequester(requester),
+		stream.WithActivityFilters(func(context.Context, stream.Activity) (stream.Verdict, error) {
+			return stream.Verdict{}, errors.New("classifier down")
+		}),
+	)
+	require.NoError(t, err)
+	requester.reset()
+	err = failing.AddToMany(context.Background(), stream.Activity{Actor: "bob", Verb: "post", Object: "o"}, feed)
+	assert.EqualError(t, err, "activity filter: classifier down")
+	assert.Empty(t, requester.recorded())
+}
+
+func TestReactionFilters(t *testing.T) {
+	var seen []stream.AddReactionRequestObject
+	client, requester := newModeratedClient(t, nil,
+		stream.WithReactionFilters(func(_ context.Context, r stream.AddReactionRequestObject) (stream.Verdict, error) {
+			seen = append(seen, r)
+			switch r.Data["text"] {
+			case "rude":
+				return stream.RejectVerdict("profanity"), nil
+			case "meh":
+				return stream.FlagVerdict("suspicious"), nil
+			}
+			return stream.AllowVerdict(), nil
+		}),
+	)
+	reactions := client.Reactions()
+	ctx := context.Background()
+
+	_, err := reactions.Add(ctx, stream.AddReactionRequestObject{Kind: "comment", ActivityID: "a1", UserID: "bob", Data: map[string]any{"text": "rude"}})
+	var rejected stream.ContentRejectedError
+	require.ErrorAs(t, err, &rejected)
+	assert.Equal(t, "profanity", rejected.Reason)
+	assert.Empty(t, requester.recorded())
+
+	r, err := reactions.AddChild(ctx, "parent", stream.AddReactionRequestObject{Kind: "comment", UserID: "bob", Data: map[string]any{"text": "meh"}})
+	require.NoError(t, err)
+	assert.Equal(t, "r-new", r.ID)
+	flags := sentFlags(t, requester)
+	require.Len(t, flags, 1)
+	assert.Equal(t, stream.ModerationReaction, flags[0]["entity_type"])
+	assert.Equal(t, "r-new", flags[0]["entity_id"])
+
+	requester.reset()
+	_, err = reactions.Update(ctx, "r1", map[string]any{"text": "meh"}, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"PUT /api/v1.0/reaction/r1/", "POST /api/v1.0/moderation/flag/"}, requester.paths())
+	assert.Equal(t, stream.AddReactionRequestObject{ID: "r1", Data: map[string]any{"text": "meh"}}, seen[len(seen)-1])
+
+	requester.reset()
+	_, err = reactions.AddMany(ctx, []stream.AddReactionRequestObject{{Kind: "like", ActivityID: "a1", UserID: "bob"}})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"POST /api/v1.0/reaction/"}, requester.paths())
+}
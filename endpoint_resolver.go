@@ -0,0 +1,194 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// Product identifies a Stream service having its own endpoints.
+type Product string
+
+// Products resolved by an EndpointResolver.
+const (
+	ProductAPI             Product = "api"
+	ProductAnalytics       Product = "analytics"
+	ProductPersonalization Product = "personalization"
+)
+
+// endpointCooldown is how long an endpoint is considered unhealthy after a
+// failure, so that reads prefer the other ones.
+const endpointCooldown = 30 * time.Second
+
+// EndpointResolver resolves the base URLs used for a product, in order of
+// preference: the first one is the primary, the others are used as fallbacks
+// by read requests failing with connection errors or server errors.
+// Base URLs include the path and version, such as
+// "https://us-east-api.stream-io-api.com/api/v1.0/".
+type EndpointResolver interface {
+	Endpoints(product Product) []string
+}
+
+// WithEndpointResolver sets the EndpointResolver used by the given Client,
+// taking precedence over the address, region and STREAM_URL settings.
+func WithEndpointResolver(resolver EndpointResolver) ClientOption {
+	return func(c *Client) {
+		c.resolver = resolver
+	}
+}
+
+// StaticEndpointResolver is an EndpointResolver returning fixed base URLs for
+// each product.
+type StaticEndpointResolver map[Product][]string
+
+// Endpoints returns the base URLs configured for the product.
+func (r StaticEndpointResolver) Endpoints(product Product) []string {
+	return append([]string(nil), r[product]...)
+}
+
+// RegionalEndpointResolver is an EndpointResolver using the Stream hosts of
+// the given regions, in order of preference.
+type RegionalEndpointResolver struct {
+	// Regions lists the regions to use, the first one being the primary.
+	Regions []string
+	// Version is the API version, defaulting to 1.0.
+	Version string
+	// RegionOverrides maps region names to API subdomains, extending and
+	// taking precedence over the built-in ones (such as "us-east" to
+	// "us-east-api").
+	RegionOverrides map[string]string
+	// PersonalizationOverrides maps region names to personalization host
+	// prefixes, extending and taking precedence over the built-in ones (such
+	// as "eu-west" to "dublin").
+	PersonalizationOverrides map[string]string
+}
+
+// Endpoints returns the base URLs of the product for the configured regions.
+func (r RegionalEndpointResolver) Endpoints(product Product) []string {
+	version := r.Version
+	if version == "" {
+		version = "1.0"
+	}
+	var (
+		urls []string
+		seen = make(map[string]bool)
+	)
+	for _, region := range r.Regions {
+		var u string
+		switch product {
+		case ProductAPI:
+			u = fmt.Sprintf("https://%s.%s/api/v%s/", regionSubdomain(region, "api", r.RegionOverrides), domain, version)
+		case ProductAnalytics:
+			u = fmt.Sprintf("https://%s.%s/analytics/v%s/", regionSubdomain(region, "analytics", r.RegionOverrides), domain, version)
+		case ProductPersonalization:
+			u = personalizationURL(region, r.PersonalizationOverrides)
+		default:
+			continue
+		}
+		if !seen[u] {
+			seen[u] = true
+			urls = append(urls, u)
+		}
+	}
+	return urls
+}
+
+// resolverURLBuilder is a urlBuilder backed by an EndpointResolver.
+type resolverURLBuilder struct {
+	resolver EndpointResolver
+	product  Product
+}
+
+func (b resolverURLBuilder) url() string {
+	if urls := b.urls(); len(urls) > 0 {
+		return urls[0]
+	}
+	return ""
+}
+
+func (b resolverURLBuilder) urls() []string {
+	return b.resolver.Endpoints(b.product)
+}
+
+// endpointHealth tracks the endpoints which recently failed.
+type endpointHealth struct {
+	mu        sync.Mutex
+	now       func() time.Time
+	unhealthy map[string]time.Time
+}
+
+func newEndpointHealth() *endpointHealth {
+	return &endpointHealth{
+		now:       time.Now,
+		unhealthy: make(map[string]time.Time),
+	}
+}
+
+func hostKey(u *url.URL) string {
+	return u.Scheme + "://" + u.Host
+}
+
+// report records the outcome of a request performed against u.
+func (h *endpointHealth) report(ctx context.Context, u *url.URL, err error) {
+	if h == nil {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	switch {
+	case err == nil:
+		delete(h.unhealthy, hostKey(u))
+	case isFailoverError(ctx, err):
+		h.unhealthy[hostKey(u)] = h.now().Add(endpointCooldown)
+	}
+}
+
+// healthy tells whether u didn't fail recently.
+func (h *endpointHealth) healthy(u *url.URL) bool {
+	if h == nil {
+		return true
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	until, ok := h.unhealthy[hostKey(u)]
+	return !ok || h.now().After(until)
+}
+
+// order sorts the given URLs putting the healthy ones first, keeping their
+// relative order otherwise.
+func (h *endpointHealth) order(urls []*url.URL) []*url.URL {
+	healthy := make([]*url.URL, 0, len(urls))
+	var unhealthy []*url.URL
+	for _, u := range urls {
+		if h.healthy(u) {
+			healthy = append(healthy, u)
+		} else {
+			unhealthy = append(unhealthy, u)
+		}
+	}
+	return append(healthy, unhealthy...)
+}
+
+func isReadMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
+
+// isFailoverError tells whether err is caused by the endpoint being
+// unreachable or failing, rather than by the request itself.
+func isFailoverError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var terr transportError
+	if errors.As(err, &terr) {
+		return true
+	}
+	if apiErr, ok := ToAPIError(err); ok {
+		return apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
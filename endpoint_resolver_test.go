@@ -0,0 +1,125 @@
+package stream_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestRegionalEndpointResolver(t *testing.T) {
+	r := stream.RegionalEndpointResolver{
+		Regions:                  []string{"us-east", "eu-west", "us-east", "custom"},
+		RegionOverrides:          map[string]string{"custom": "custom-api"},
+		PersonalizationOverrides: map[string]string{"custom": "custom-perso"},
+	}
+	assert.Equal(t, []string{
+		"https://us-east-api.stream-io-api.com/api/v1.0/",
+		"https://eu-west-api.stream-io-api.com/api/v1.0/",
+		"https://custom-api.stream-io-api.com/api/v1.0/",
+	}, r.Endpoints(stream.ProductAPI))
+	assert.Equal(t, []string{
+		"https://us-east-api.stream-io-api.com/analytics/v1.0/",
+		"https://eu-west-api.stream-io-api.com/analytics/v1.0/",
+		"https://custom-api.stream-io-api.com/analytics/v1.0/",
+	}, r.Endpoints(stream.ProductAnalytics))
+	assert.Equal(t, []string{
+		"https://personalization.stream-io-api.com/personalization/v1.0/",
+		"https://dublin-personalization.stream-io-api.com/personalization/v1.0/",
+		"https://custom-perso-personalization.stream-io-api.com/personalization/v1.0/",
+	}, r.Endpoints(stream.ProductPersonalization))
+	assert.Empty(t, r.Endpoints(stream.Product("unknown")))
+}
+
+// hosts returns the hosts the recorded requests were sent to.
+func hosts(requester *recordingRequester) []string {
+	var hosts []string
+	for _, r := range requester.recorded() {
+		hosts = append(hosts, r.host)
+	}
+	return hosts
+}
+
+func TestEndpointResolverFailover(t *testing.T) {
+	// the requests sent to the hosts in down fail
+	down := map[string]error{"primary": errors.New("connection refused")}
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		if err, ok := down[r.host]; ok {
+			if err != nil {
+				return nil, err
+			}
+			return &http.Response{
+				StatusCode: http.StatusBadGateway,
+				Body:       io.NopCloser(strings.NewReader("bad gateway")),
+			}, nil
+		}
+		return jsonResponse(http.StatusOK, `{}`), nil
+	}, stream.WithEndpointResolver(stream.StaticEndpointResolver{
+		stream.ProductAPI: {"https://primary/api/v1.0/", "https://secondary/api/v1.0/"},
+	}))
+	ctx := context.Background()
+
+	// reads fail over and then prefer the healthy host
+	_, err := client.Reactions().Get(ctx, "r1")
+	require.NoError(t, err)
+	_, err = client.Users().Get(ctx, "u1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"primary", "secondary", "secondary"}, hosts(requester))
+
+	// writes target the primary host only
+	requester.reset()
+	_, err = client.Users().Add(ctx, stream.User{ID: "u1"}, false)
+	require.Error(t, err)
+	assert.Equal(t, []string{"primary"}, hosts(requester))
+
+	// server errors fail over too
+	requester.reset()
+	down = map[string]error{"secondary": nil}
+	_, err = client.Reactions().Get(ctx, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"secondary", "primary"}, hosts(requester))
+
+	// the primary host is preferred again once healthy
+	requester.reset()
+	down = nil
+	_, err = client.Reactions().Get(ctx, "r1")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"primary"}, hosts(requester))
+}
+
+func TestEndpointResolverProducts(t *testing.T) {
+	client, requester := newRecordingClient(t, nil,
+		stream.WithEndpointResolver(stream.RegionalEndpointResolver{Regions: []string{"eu-west"}}),
+	)
+	ctx := context.Background()
+
+	_, err := client.Personalization().Get(ctx, "etoro", nil)
+	require.NoError(t, err)
+	_, err = client.Analytics().TrackEngagement(ctx, stream.EngagementEvent{})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"dublin-personalization.stream-io-api.com",
+		"eu-west-api.stream-io-api.com",
+	}, hosts(requester))
+}
+
+func TestEndpointResolverMissingProduct(t *testing.T) {
+	client, requester := newRecordingClient(t, nil,
+		stream.WithEndpointResolver(stream.StaticEndpointResolver{
+			stream.ProductAPI: {"https://primary/api/v1.0/"},
+		}),
+	)
+
+	_, err := client.Personalization().Get(context.Background(), "follow_recommendations", nil)
+	assert.EqualError(t, err, `no endpoints resolved for product "personalization"`)
+	_, err = client.Analytics().RedirectAndTrack("https://example.com")
+	assert.EqualError(t, err, `no endpoints resolved for product "analytics"`)
+	assert.Empty(t, hosts(requester))
+}
@@ -2,7 +2,6 @@ package stream
 
 import (
 	"encoding/json"
-	"errors"
 	"fmt"
 	"reflect"
 	"strings"
@@ -46,43 +45,77 @@ type EnrichedReaction struct {
 }
 
 // UnmarshalJSON decodes the provided JSON payload into the EnrichedActivity. It's required
-// because of the custom JSON fields and time formats.
+// because of the custom JSON fields and time formats. Known fields are decoded
+// straight from the payload, while the remaining ones are collected in Extra.
 func (a *EnrichedActivity) UnmarshalJSON(b []byte) error {
-	var data map[string]any
-	if err := json.Unmarshal(b, &data); err != nil {
-		return err
-	}
-
-	if _, ok := data["to"]; ok {
-		tos := data["to"].([]any)
-		simpleTos := make([]string, len(tos))
-		for i := range tos {
-			switch to := tos[i].(type) {
-			case string:
-				simpleTos[i] = to
-			case []any:
-				tos, ok := to[0].(string)
-				if !ok {
-					return errors.New("invalid format for to targets")
-				}
-				simpleTos[i] = tos
+	return decodeObject(b, func(key string, dec *json.Decoder) error {
+		var err error
+		switch key {
+		case "id":
+			a.ID, err = decodeString(dec)
+		case "actor":
+			err = dec.Decode(&a.Actor)
+		case "verb":
+			a.Verb, err = decodeString(dec)
+		case "object":
+			err = dec.Decode(&a.Object)
+		case "foreign_id":
+			err = a.decodeForeignID(dec)
+		case "target":
+			err = dec.Decode(&a.Target)
+		case "time":
+			a.Time, err = decodeTime(dec)
+		case "origin":
+			err = dec.Decode(&a.Origin)
+		case "to":
+			a.To, err = decodeToTargets(dec)
+		case "score":
+			a.Score, err = decodeFloat(dec)
+		case "reaction_counts":
+			err = dec.Decode(&a.ReactionCounts)
+		case "own_reactions":
+			err = dec.Decode(&a.OwnReactions)
+		case "latest_reactions":
+			err = dec.Decode(&a.LatestReactions)
+		default:
+			var v any
+			if err = dec.Decode(&v); err == nil {
+				a.setExtra(key, v)
 			}
 		}
-		data["to"] = simpleTos
-	}
+		return err
+	})
+}
 
-	if v, ok := data["foreign_id"]; ok { // handle activity reference in foreign id
-		if val, ok := v.(map[string]any); ok {
-			id, ok := val["id"].(string)
-			if !ok {
-				return fmt.Errorf("invalid format for enriched referenced activity id: %v", val["id"])
-			}
-			data["foreign_id_ref"] = data["foreign_id"]
-			data["foreign_id"] = "SA:" + id
+// decodeForeignID handles activity references in the foreign id, which are
+// stored as "SA:<id>" while the referenced activity is kept in Extra.
+func (a *EnrichedActivity) decodeForeignID(dec *json.Decoder) error {
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return err
+	}
+	switch val := v.(type) {
+	case nil:
+	case string:
+		a.ForeignID = val
+	case map[string]any:
+		id, ok := val["id"].(string)
+		if !ok {
+			return fmt.Errorf("invalid format for enriched referenced activity id: %v", val["id"])
 		}
+		a.ForeignID = "SA:" + id
+		a.setExtra("foreign_id_ref", val)
+	default:
+		return fmt.Errorf("invalid format for foreign id: %v", val)
 	}
+	return nil
+}
 
-	return a.decode(data)
+func (a *EnrichedActivity) setExtra(key string, v any) {
+	if a.Extra == nil {
+		a.Extra = make(map[string]any)
+	}
+	a.Extra[key] = v
 }
 
 // MarshalJSON encodes the EnrichedActivity to a valid JSON bytes slice. It's required because of
@@ -113,20 +146,6 @@ func (a EnrichedActivity) MarshalJSON() ([]byte, error) {
 	return json.Marshal(data)
 }
 
-func (a *EnrichedActivity) decode(data map[string]any) error {
-	meta, err := decodeData(data, a)
-	if err != nil {
-		return err
-	}
-	if len(meta.Unused) > 0 {
-		a.Extra = make(map[string]any)
-		for _, k := range meta.Unused {
-			a.Extra[k] = data[k]
-		}
-	}
-	return nil
-}
-
 // EnrichedActivityGroup is a group of enriched Activities obtained from aggregated feeds.
 type EnrichedActivityGroup struct {
 	baseActivityGroup
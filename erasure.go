@@ -0,0 +1,484 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+)
+
+// ErrErasureIncomplete is returned by EraseUser when the verification pass
+// still finds data of the user.
+var ErrErasureIncomplete = errors.New("user data left after erasure")
+
+const erasurePageSize = 100
+
+// ErasureStep is a step of the user erasure workflow. Steps run in the order
+// of the constants below.
+type ErasureStep string
+
+const (
+	ErasureStepReactions   ErasureStep = "reactions"
+	ErasureStepActivities  ErasureStep = "activities"
+	ErasureStepFollows     ErasureStep = "follows"
+	ErasureStepCollections ErasureStep = "collections"
+	ErasureStepUser        ErasureStep = "user"
+	ErasureStepVerify      ErasureStep = "verify"
+)
+
+var erasureSteps = []ErasureStep{
+	ErasureStepReactions,
+	ErasureStepActivities,
+	ErasureStepFollows,
+	ErasureStepCollections,
+	ErasureStepUser,
+	ErasureStepVerify,
+}
+
+// ErasurePlan lists the data of a user which isn't discoverable from the
+// user ID alone. It's used by both EraseUser and ExportUserData.
+type ErasurePlan struct {
+	// Feeds are the slugs of the user's feeds, whose activities are removed
+	// and which are unfollowed by their followers and unfollow their targets.
+	// They're read according to the type of their group registered with
+	// WithFeedGroups, and as flat feeds if not registered.
+	Feeds []string
+	// Collections maps collection names to the IDs of the objects owned by
+	// the user.
+	Collections map[string][]string
+}
+
+// ErasureProgress is reported after every completed step.
+type ErasureProgress struct {
+	Step ErasureStep
+	// Count is the number of items removed by the step, or found by it in
+	// dry-run mode.
+	Count int
+}
+
+// ErasureReport describes what EraseUser removed, or would remove in dry-run
+// mode.
+type ErasureReport struct {
+	UserID string
+	DryRun bool
+	// Reactions are the IDs of the reactions of the user.
+	Reactions []string
+	// Activities are the IDs of the activities of the user's feeds.
+	Activities map[FeedID][]string
+	// Unfollows are the follow relationships from and to the user's feeds.
+	Unfollows []UnfollowRelationship
+	// Collections are the IDs of the existing objects of the user.
+	Collections map[string][]string
+	// Completed lists the steps completed by this run.
+	Completed []ErasureStep
+	// Leftovers describes the data found by the verification pass.
+	Leftovers []string
+	// Err is the error which stopped the erasure, if any.
+	Err error
+}
+
+// EraseUserOption configures EraseUser.
+type EraseUserOption func(*eraseUserOptions)
+
+type eraseUserOptions struct {
+	dryRun   bool
+	resume   ErasureStep
+	progress func(ErasureProgress)
+	batch    []BatchOption
+}
+
+// WithErasureDryRun lists the data of the user without removing it.
+func WithErasureDryRun() EraseUserOption {
+	return func(o *eraseUserOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithErasureResume skips the steps before the given one, to resume an
+// interrupted erasure from its last reported progress.
+func WithErasureResume(step ErasureStep) EraseUserOption {
+	return func(o *eraseUserOptions) {
+		o.resume = step
+	}
+}
+
+// WithErasureProgress sets the function called after every completed step.
+func WithErasureProgress(fn func(ErasureProgress)) EraseUserOption {
+	return func(o *eraseUserOptions) {
+		o.progress = fn
+	}
+}
+
+// WithErasureBatchOptions sets the options of the removal batches.
+func WithErasureBatchOptions(opts ...BatchOption) EraseUserOption {
+	return func(o *eraseUserOptions) {
+		o.batch = append(o.batch, opts...)
+	}
+}
+
+// EraseUser removes all the data of a user: their reactions, the activities
+// of their feeds, the follows from and to their feeds, the collection objects
+// they own and finally the user itself. A verification pass then checks that
+// nothing is left, returning ErrErasureIncomplete otherwise.
+//
+// Every step lists the data before removing it and tolerates data already
+// removed, so an interrupted erasure can be run again, optionally skipping
+// the completed steps with WithErasureResume. A feed with more follows than
+// the API can page through stops the erasure with ErrTooManyFollows, rather
+// than leaving some of them behind.
+func (c *Client) EraseUser(ctx context.Context, userID string, plan ErasurePlan, opts ...EraseUserOption) (*ErasureReport, error) {
+	o := eraseUserOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	report := &ErasureReport{UserID: userID, DryRun: o.dryRun}
+	err := c.eraseUser(ctx, userID, plan, o, report)
+	report.Err = err
+	return report, err
+}
+
+func (c *Client) eraseUser(ctx context.Context, userID string, plan ErasurePlan, o eraseUserOptions, report *ErasureReport) error {
+	if userID == "" {
+		return errInvalidUserID
+	}
+	feeds := make([]Feed, len(plan.Feeds))
+	for i, slug := range plan.Feeds {
+		feed, err := newFeed(slug, userID, c)
+		if err != nil {
+			return err
+		}
+		feeds[i] = feed
+	}
+
+	steps := erasureSteps
+	if o.resume != "" {
+		i := 0
+		for i < len(steps) && steps[i] != o.resume {
+			i++
+		}
+		if i == len(steps) {
+			return fmt.Errorf("unknown erasure step %q", o.resume)
+		}
+		steps = steps[i:]
+	}
+	for _, step := range steps {
+		if step == ErasureStepVerify && o.dryRun {
+			break
+		}
+		var (
+			count int
+			err   error
+		)
+		switch step {
+		case ErasureStepReactions:
+			count, err = c.eraseReactions(ctx, userID, o, report)
+		case ErasureStepActivities:
+			count, err = c.eraseActivities(ctx, feeds, o, report)
+		case ErasureStepFollows:
+			count, err = c.eraseFollows(ctx, feeds, o, report)
+		case ErasureStepCollections:
+			count, err = c.eraseCollections(ctx, plan.Collections, o, report)
+		case ErasureStepUser:
+			count, err = c.eraseUserObject(ctx, userID, o)
+		case ErasureStepVerify:
+			err = c.verifyErasure(ctx, userID, feeds, plan.Collections, report)
+		}
+		if err != nil {
+			return fmt.Errorf("cannot erase %s of user %s: %w", step, userID, err)
+		}
+		report.Completed = append(report.Completed, step)
+		if o.progress != nil {
+			o.progress(ErasureProgress{Step: step, Count: count})
+		}
+	}
+	if len(report.Leftovers) > 0 {
+		return fmt.Errorf("%w: %d items of user %s", ErrErasureIncomplete, len(report.Leftovers), userID)
+	}
+	return nil
+}
+
+// isNotFound tells whether err is a 404 API error, meaning that the data was
+// already removed.
+func isNotFound(err error) bool {
+	apiErr, ok := ToAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// pageUserReactions calls fn with every reaction of the user, or only with
+// the first one unless all is set.
+func (c *Client) pageUserReactions(ctx context.Context, userID string, all bool, fn func(Reaction) error) error {
+	reactions := c.Reactions()
+	limit := 1
+	if all {
+		limit = erasurePageSize
+	}
+	resp, err := reactions.Filter(ctx, ByUserID(userID), WithLimit(limit))
+	for err == nil {
+		for _, r := range resp.Results {
+			if err := fn(r); err != nil {
+				return err
+			}
+		}
+		if !all || resp.Next == "" {
+			return nil
+		}
+		resp, err = reactions.GetNextPageFilteredReactions(ctx, resp)
+	}
+	return err
+}
+
+func (c *Client) userReactions(ctx context.Context, userID string, all bool) ([]string, error) {
+	var ids []string
+	err := c.pageUserReactions(ctx, userID, all, func(r Reaction) error {
+		ids = append(ids, r.ID)
+		return nil
+	})
+	return ids, err
+}
+
+func (c *Client) eraseReactions(ctx context.Context, userID string, o eraseUserOptions, report *ErasureReport) (int, error) {
+	ids, err := c.userReactions(ctx, userID, true)
+	if err != nil {
+		return 0, err
+	}
+	report.Reactions = ids
+	if o.dryRun {
+		return len(ids), nil
+	}
+	reactions := c.Reactions()
+	result := runBatch(ctx, len(ids), 1, func(i int) string { return ids[i] }, o.batch, func(ctx context.Context, start, _ int) error {
+		if _, err := reactions.Delete(ctx, ids[start]); err != nil && !isNotFound(err) {
+			return err
+		}
+		return nil
+	})
+	return len(result.Succeeded()), result.Err()
+}
+
+// feedActivities returns the IDs of the activities of the feed, or only of the
+// first page unless all is set. Feeds are read according to their type, see
+// ExportActivities.
+func (c *Client) feedActivities(ctx context.Context, feed Feed, all bool) ([]string, error) {
+	limit := 1
+	if all {
+		limit = erasurePageSize
+	}
+	var (
+		ids   []string
+		after string
+	)
+	for {
+		page, err := c.activityPage(ctx, feed, limit, after)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range page.activities {
+			ids = append(ids, a.ID)
+		}
+		after = page.last
+		if !all || !page.full {
+			return ids, nil
+		}
+	}
+}
+
+func (c *Client) eraseActivities(ctx context.Context, feeds []Feed, o eraseUserOptions, report *ErasureReport) (int, error) {
+	report.Activities = make(map[FeedID][]string, len(feeds))
+	count := 0
+	for _, feed := range feeds {
+		id := FeedIDOf(feed)
+		seen := make(map[string]bool)
+		for {
+			ids, err := c.feedActivities(ctx, feed, true)
+			if err != nil {
+				return count, err
+			}
+			var fresh []string
+			for _, aid := range ids {
+				if !seen[aid] {
+					seen[aid] = true
+					fresh = append(fresh, aid)
+				}
+			}
+			if len(fresh) == 0 {
+				break
+			}
+			report.Activities[id] = append(report.Activities[id], fresh...)
+			if o.dryRun {
+				count += len(fresh)
+				break
+			}
+			result, err := c.RemoveActivitiesByID(ctx, feed, fresh, o.batch...)
+			count += len(result.Succeeded())
+			if err != nil {
+				return count, err
+			}
+			// the groups of aggregated and notification feeds only hold their
+			// latest activities, the older ones showing up once removed
+			if c.feedType(feed.Slug()) == FeedTypeFlat {
+				break
+			}
+		}
+	}
+	return count, nil
+}
+
+func (c *Client) feedFollows(ctx context.Context, feed Feed) ([]UnfollowRelationship, error) {
+	var rels []UnfollowRelationship
+	add := func(f Follower) error {
+		rels = append(rels, UnfollowRelationship{Source: f.FeedID, Target: f.TargetID})
+		return nil
+	}
+	if err := pageFollows(ctx, c.followingPager(feed), add); err != nil {
+		return nil, err
+	}
+	if err := pageFollows(ctx, c.followersPager(feed), add); err != nil {
+		return nil, err
+	}
+	return rels, nil
+}
+
+func (c *Client) eraseFollows(ctx context.Context, feeds []Feed, o eraseUserOptions, report *ErasureReport) (int, error) {
+	seen := make(map[UnfollowRelationship]bool)
+	for _, feed := range feeds {
+		rels, err := c.feedFollows(ctx, feed)
+		if err != nil {
+			return 0, err
+		}
+		for _, r := range rels {
+			if !seen[r] {
+				seen[r] = true
+				report.Unfollows = append(report.Unfollows, r)
+			}
+		}
+	}
+	if o.dryRun || len(report.Unfollows) == 0 {
+		return len(report.Unfollows), nil
+	}
+	result, err := c.UnfollowManyBatched(ctx, report.Unfollows, o.batch...)
+	return len(result.Succeeded()), err
+}
+
+// pageCollectionObjects calls fn with every existing object among the given
+// ones, by collection name.
+func (c *Client) pageCollectionObjects(ctx context.Context, collections map[string][]string, fn func(name string, obj GetCollectionResponseObject) error) error {
+	names := make([]string, 0, len(collections))
+	for name := range collections {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if len(collections[name]) == 0 {
+			continue
+		}
+		resp, err := c.Collections().Select(ctx, name, collections[name]...)
+		if err != nil {
+			return err
+		}
+		for _, obj := range resp.Objects {
+			if err := fn(name, obj); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (c *Client) existingObjects(ctx context.Context, collections map[string][]string) (map[string][]string, error) {
+	existing := make(map[string][]string)
+	err := c.pageCollectionObjects(ctx, collections, func(name string, obj GetCollectionResponseObject) error {
+		existing[name] = append(existing[name], strings.TrimPrefix(obj.ForeignID, name+":"))
+		return nil
+	})
+	return existing, err
+}
+
+func (c *Client) eraseCollections(ctx context.Context, collections map[string][]string, o eraseUserOptions, report *ErasureReport) (int, error) {
+	existing, err := c.existingObjects(ctx, collections)
+	if err != nil {
+		return 0, err
+	}
+	report.Collections = existing
+	count := 0
+	for name, ids := range existing {
+		if !o.dryRun {
+			if _, err := c.Collections().DeleteMany(ctx, name, ids...); err != nil {
+				return count, err
+			}
+		}
+		count += len(ids)
+	}
+	return count, nil
+}
+
+func (c *Client) eraseUserObject(ctx context.Context, userID string, o eraseUserOptions) (int, error) {
+	if o.dryRun {
+		if _, err := c.Users().Get(ctx, userID); err != nil {
+			if isNotFound(err) {
+				return 0, nil
+			}
+			return 0, err
+		}
+		return 1, nil
+	}
+	if _, err := c.Users().Delete(ctx, userID); err != nil {
+		if isNotFound(err) {
+			return 0, nil
+		}
+		return 0, err
+	}
+	return 1, nil
+}
+
+// verifyErasure looks for any data of the user left, adding it to the
+// report's leftovers.
+func (c *Client) verifyErasure(ctx context.Context, userID string, feeds []Feed, collections map[string][]string, report *ErasureReport) error {
+	reactions, err := c.userReactions(ctx, userID, false)
+	if err != nil {
+		return err
+	}
+	if len(reactions) > 0 {
+		report.Leftovers = append(report.Leftovers, "reactions")
+	}
+	for _, feed := range feeds {
+		activities, err := c.feedActivities(ctx, feed, false)
+		if err != nil {
+			return err
+		}
+		if len(activities) > 0 {
+			report.Leftovers = append(report.Leftovers, fmt.Sprintf("activities of %s", feed.ID()))
+		}
+		following, err := c.getFollowing(ctx, feed, WithFollowingLimit(1))
+		if err != nil {
+			return err
+		}
+		if len(following.Results) > 0 {
+			report.Leftovers = append(report.Leftovers, fmt.Sprintf("follows of %s", feed.ID()))
+		}
+		followers, err := c.getFollowers(ctx, feed, WithFollowersLimit(1))
+		if err != nil {
+			return err
+		}
+		if len(followers.Results) > 0 {
+			report.Leftovers = append(report.Leftovers, fmt.Sprintf("followers of %s", feed.ID()))
+		}
+	}
+	existing, err := c.existingObjects(ctx, collections)
+	if err != nil {
+		return err
+	}
+	for name, ids := range existing {
+		for _, id := range ids {
+			report.Leftovers = append(report.Leftovers, fmt.Sprintf("collection object %s:%s", name, id))
+		}
+	}
+	if _, err := c.Users().Get(ctx, userID); err == nil {
+		report.Leftovers = append(report.Leftovers, "user")
+	} else if !isNotFound(err) {
+		return err
+	}
+	sort.Strings(report.Leftovers)
+	return nil
+}
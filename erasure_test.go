@@ -0,0 +1,281 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// userData is a fake backend holding the data of users.
+type userData struct {
+	mu          sync.Mutex
+	users       map[string]bool
+	reactions   map[string]string
+	activities  map[string][]string
+	follows     map[string]string
+	objects     map[string]bool
+	keepObjects bool
+	// groupSize is how many of their latest activities the notification
+	// feeds show, in a single group
+	groupSize int
+	deletes   int
+}
+
+func (d *userData) Do(req *http.Request) (*http.Response, error) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	path := strings.TrimPrefix(req.URL.Path, "/api/v1.0/")
+	parts := strings.Split(strings.TrimSuffix(path, "/"), "/")
+	query := req.URL.Query()
+	respond := func(v any) (*http.Response, error) {
+		b, _ := json.Marshal(v)
+		return jsonResponse(http.StatusOK, string(b)), nil
+	}
+	notFound := jsonResponse(http.StatusNotFound, `{"detail":"not found","status_code":404}`)
+	if req.Method == http.MethodDelete {
+		d.deletes++
+	}
+
+	switch {
+	case parts[0] == "reaction" && req.Method == http.MethodGet:
+		var results []map[string]string
+		for id, user := range d.reactions {
+			if user == parts[2] {
+				results = append(results, map[string]string{"id": id})
+			}
+		}
+		return respond(map[string]any{"results": results})
+	case parts[0] == "reaction" && req.Method == http.MethodDelete:
+		if _, ok := d.reactions[parts[1]]; !ok {
+			return notFound, nil
+		}
+		delete(d.reactions, parts[1])
+		return respond(map[string]any{})
+	case parts[0] == "feed" && parts[len(parts)-1] == "follows":
+		source := parts[1] + ":" + parts[2]
+		var results []stream.Follower
+		if query.Get("offset") == "" || query.Get("offset") == "0" {
+			for s, t := range d.follows {
+				if s == source {
+					results = append(results, stream.Follower{FeedID: s, TargetID: t})
+				}
+			}
+		}
+		return respond(map[string]any{"results": results})
+	case parts[0] == "feed" && parts[len(parts)-1] == "followers":
+		target := parts[1] + ":" + parts[2]
+		var results []stream.Follower
+		if query.Get("offset") == "" || query.Get("offset") == "0" {
+			for s, t := range d.follows {
+				if t == target {
+					results = append(results, stream.Follower{FeedID: s, TargetID: t})
+				}
+			}
+		}
+		return respond(map[string]any{"results": results})
+	case parts[0] == "feed" && parts[1] == "notification" && req.Method == http.MethodGet:
+		ids := d.activities[parts[1]+":"+parts[2]]
+		if len(ids) > d.groupSize {
+			ids = ids[:d.groupSize]
+		}
+		var results []stream.NotificationFeedResult
+		if len(ids) > 0 && query.Get("id_lt") == "" {
+			var g stream.NotificationFeedResult
+			g.ID = "g1"
+			for _, id := range ids {
+				g.Activities = append(g.Activities, stream.Activity{ID: id})
+			}
+			results = append(results, g)
+		}
+		return respond(map[string]any{"results": results})
+	case parts[0] == "feed" && req.Method == http.MethodGet:
+		feed := parts[1] + ":" + parts[2]
+		var results []map[string]string
+		for _, id := range d.activities[feed] {
+			if idLT := query.Get("id_lt"); idLT == "" || id < idLT {
+				results = append(results, map[string]string{"id": id})
+			}
+		}
+		return respond(map[string]any{"results": results})
+	case parts[0] == "feed" && req.Method == http.MethodDelete:
+		feed := parts[1] + ":" + parts[2]
+		for i, id := range d.activities[feed] {
+			if id == parts[3] {
+				d.activities[feed] = append(d.activities[feed][:i], d.activities[feed][i+1:]...)
+				break
+			}
+		}
+		return respond(map[string]any{"removed": parts[3]})
+	case parts[0] == "unfollow_many":
+		body, _ := io.ReadAll(req.Body)
+		var rels []stream.UnfollowRelationship
+		_ = json.Unmarshal(body, &rels)
+		for _, r := range rels {
+			if d.follows[r.Source] == r.Target {
+				delete(d.follows, r.Source)
+			}
+		}
+		return respond(map[string]any{})
+	case parts[0] == "collections" && req.Method == http.MethodGet:
+		var data []stream.GetCollectionResponseObject
+		for _, id := range strings.Split(query.Get("foreign_ids"), ",") {
+			if d.objects[id] {
+				data = append(data, stream.GetCollectionResponseObject{ForeignID: id})
+			}
+		}
+		return respond(map[string]any{"response": map[string]any{"data": data}})
+	case parts[0] == "collections" && req.Method == http.MethodDelete:
+		if !d.keepObjects {
+			for _, id := range strings.Split(query.Get("ids"), ",") {
+				delete(d.objects, query.Get("collection_name")+":"+id)
+			}
+		}
+		return respond(map[string]any{})
+	case parts[0] == "user":
+		if !d.users[parts[1]] {
+			return notFound, nil
+		}
+		if req.Method == http.MethodDelete {
+			delete(d.users, parts[1])
+		}
+		return respond(map[string]any{"id": parts[1]})
+	}
+	return notFound, nil
+}
+
+func newUserData() *userData {
+	return &userData{
+		users:     map[string]bool{"u1": true, "u2": true},
+		reactions: map[string]string{"r1": "u1", "r2": "u1", "r3": "u2"},
+		activities: map[string][]string{
+			"user:u1":     {"a2", "a1"},
+			"timeline:u1": {"a5"},
+			"user:u2":     {"a3"},
+		},
+		follows: map[string]string{"timeline:u1": "user:u2", "timeline:u2": "user:u1"},
+		objects: map[string]bool{"food:1": true, "food:2": true, "food:3": true},
+	}
+}
+
+var erasurePlan = stream.ErasurePlan{
+	Feeds:       []string{"user", "timeline"},
+	Collections: map[string][]string{"food": {"1", "2", "9"}},
+}
+
+func TestEraseUser(t *testing.T) {
+	data := newUserData()
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(data))
+	require.NoError(t, err)
+
+	var progress []stream.ErasureProgress
+	report, err := client.EraseUser(context.Background(), "u1", erasurePlan,
+		stream.WithErasureProgress(func(p stream.ErasureProgress) { progress = append(progress, p) }))
+	require.NoError(t, err)
+	sort.Strings(report.Reactions)
+	assert.Equal(t, []string{"r1", "r2"}, report.Reactions)
+	assert.Equal(t, map[stream.FeedID][]string{
+		stream.MustParseFeedID("user:u1"):     {"a2", "a1"},
+		stream.MustParseFeedID("timeline:u1"): {"a5"},
+	}, report.Activities)
+	assert.ElementsMatch(t, []stream.UnfollowRelationship{
+		{Source: "timeline:u2", Target: "user:u1"},
+		{Source: "timeline:u1", Target: "user:u2"},
+	}, report.Unfollows)
+	assert.Equal(t, map[string][]string{"food": {"1", "2"}}, report.Collections)
+	assert.Empty(t, report.Leftovers)
+	assert.Equal(t, []stream.ErasureProgress{
+		{Step: stream.ErasureStepReactions, Count: 2},
+		{Step: stream.ErasureStepActivities, Count: 3},
+		{Step: stream.ErasureStepFollows, Count: 2},
+		{Step: stream.ErasureStepCollections, Count: 2},
+		{Step: stream.ErasureStepUser, Count: 1},
+		{Step: stream.ErasureStepVerify},
+	}, progress)
+
+	assert.Equal(t, map[string]string{"r3": "u2"}, data.reactions)
+	assert.Equal(t, map[string][]string{"user:u1": {}, "timeline:u1": {}, "user:u2": {"a3"}}, data.activities)
+	assert.Empty(t, data.follows)
+	assert.Equal(t, map[string]bool{"food:3": true}, data.objects)
+	assert.Equal(t, map[string]bool{"u2": true}, data.users)
+
+	report, err = client.EraseUser(context.Background(), "u1", erasurePlan)
+	require.NoError(t, err)
+	assert.Len(t, report.Completed, 6)
+}
+
+func TestEraseUserNotificationFeed(t *testing.T) {
+	data := newUserData()
+	data.activities["notification:u1"] = []string{"n3", "n2", "n1"}
+	data.groupSize = 2
+	client, err := stream.New("key", "secret",
+		stream.WithHTTPRequester(data),
+		stream.WithFeedGroups(stream.FeedGroup{Slug: "notification", Type: stream.FeedTypeNotification}),
+	)
+	require.NoError(t, err)
+
+	report, err := client.EraseUser(context.Background(), "u1", stream.ErasurePlan{Feeds: []string{"notification"}})
+	require.NoError(t, err)
+	assert.Equal(t, map[stream.FeedID][]string{
+		stream.MustParseFeedID("notification:u1"): {"n3", "n2", "n1"},
+	}, report.Activities)
+	assert.Empty(t, report.Leftovers)
+	assert.Empty(t, data.activities["notification:u1"])
+}
+
+func TestEraseUserDryRun(t *testing.T) {
+	data := newUserData()
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(data))
+	require.NoError(t, err)
+
+	report, err := client.EraseUser(context.Background(), "u1", erasurePlan, stream.WithErasureDryRun())
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Len(t, report.Reactions, 2)
+	assert.Len(t, report.Unfollows, 2)
+	assert.Equal(t, map[string][]string{"food": {"1", "2"}}, report.Collections)
+	assert.NotContains(t, report.Completed, stream.ErasureStepVerify)
+	assert.Zero(t, data.deletes)
+	assert.Len(t, data.reactions, 3)
+}
+
+func TestEraseUserResumeAndVerify(t *testing.T) {
+	data := newUserData()
+	data.keepObjects = true
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(data))
+	require.NoError(t, err)
+
+	report, err := client.EraseUser(context.Background(), "u1", erasurePlan,
+		stream.WithErasureResume(stream.ErasureStepCollections))
+	require.ErrorIs(t, err, stream.ErrErasureIncomplete)
+	assert.Equal(t, []stream.ErasureStep{
+		stream.ErasureStepCollections,
+		stream.ErasureStepUser,
+		stream.ErasureStepVerify,
+	}, report.Completed)
+	assert.Equal(t, []string{
+		"activities of timeline:u1",
+		"activities of user:u1",
+		"collection object food:1",
+		"collection object food:2",
+		"followers of user:u1",
+		"follows of timeline:u1",
+		"reactions",
+	}, report.Leftovers)
+	assert.Equal(t, err, report.Err)
+	assert.Len(t, data.reactions, 3)
+
+	_, err = client.EraseUser(context.Background(), "u1", erasurePlan, stream.WithErasureResume("nope"))
+	assert.Error(t, err)
+	_, err = client.EraseUser(context.Background(), "", erasurePlan)
+	assert.Error(t, err)
+}
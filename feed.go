@@ -49,6 +49,11 @@ func (f *feed) ID() string {
 	return fmt.Sprintf("%s%s%s", f.slug, feedSlugIDSeparator, f.userID)
 }
 
+// FeedID returns the feed ID.
+func (f *feed) FeedID() FeedID {
+	return FeedID{Slug: f.slug, UserID: f.userID}
+}
+
 // Slug returns the feed's slug.
 func (f *feed) Slug() string {
 	return f.slug
@@ -60,9 +65,8 @@ func (f *feed) UserID() string {
 }
 
 func newFeed(slug, userID string, client *Client) (*feed, error) {
-	ok := userIDRegex.MatchString(userID)
-	if !ok {
-		return nil, errInvalidUserID
+	if err := (FeedID{Slug: slug, UserID: userID}).Validate(); err != nil {
+		return nil, err
 	}
 	return &feed{userID: userID, slug: slug, client: client}, nil
 }
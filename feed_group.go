@@ -0,0 +1,135 @@
+package stream
+
+import (
+	"fmt"
+	"slices"
+)
+
+// FeedType is the type of the feeds of a feed group.
+type FeedType string
+
+// Feed types.
+const (
+	FeedTypeFlat         FeedType = "flat"
+	FeedTypeAggregated   FeedType = "aggregated"
+	FeedTypeNotification FeedType = "notification"
+)
+
+// FeedGroup describes a feed group configured in the Stream dashboard.
+type FeedGroup struct {
+	// Slug is the name of the feed group.
+	Slug string
+	// Type is the type of the feeds of the group.
+	Type FeedType
+	// FollowTargets lists the slugs of the feed groups that feeds of this
+	// group can follow. If empty, any flat feed group can be followed.
+	FollowTargets []string
+	// RankingMethods lists the ranking methods configured for the group. If
+	// empty, any ranking method is allowed.
+	RankingMethods []string
+}
+
+// WithFeedGroups registers the schema of the feed groups of the application.
+// Once registered, feeds can only be built for the registered groups and
+// with the matching type, GenericFeed returns typed feeds, and follows and
+// rankings are checked against the schema before performing API calls.
+func WithFeedGroups(groups ...FeedGroup) ClientOption {
+	return func(c *Client) {
+		if c.feedGroups == nil {
+			c.feedGroups = make(feedGroupRegistry, len(groups))
+		}
+		for _, g := range groups {
+			c.feedGroups[g.Slug] = g
+		}
+	}
+}
+
+// FeedGroup returns the registered feed group with the given slug, if any.
+func (c *Client) FeedGroup(slug string) (FeedGroup, bool) {
+	g, ok := c.feedGroups[slug]
+	return g, ok
+}
+
+// feedGroupRegistry maps slugs to the registered feed groups. A nil registry
+// allows everything.
+type feedGroupRegistry map[string]FeedGroup
+
+func (r feedGroupRegistry) validate() error {
+	for slug, g := range r {
+		if !slugRegex.MatchString(slug) {
+			return fmt.Errorf("invalid feed group %q: %w", slug, errInvalidSlug)
+		}
+		switch g.Type {
+		case FeedTypeFlat, FeedTypeAggregated, FeedTypeNotification:
+		default:
+			return fmt.Errorf("invalid feed group %q: unknown feed type %q", slug, g.Type)
+		}
+		for _, target := range g.FollowTargets {
+			tg, ok := r[target]
+			if !ok {
+				return fmt.Errorf("invalid feed group %q: follow target %q is not registered", slug, target)
+			}
+			if tg.Type != FeedTypeFlat {
+				return fmt.Errorf("invalid feed group %q: follow target %q is not a flat feed group", slug, target)
+			}
+		}
+	}
+	return nil
+}
+
+func (r feedGroupRegistry) lookup(slug string) (FeedGroup, error) {
+	g, ok := r[slug]
+	if !ok {
+		return FeedGroup{}, fmt.Errorf("feed group %q is not registered", slug)
+	}
+	return g, nil
+}
+
+// checkType checks that the feed group has the given type.
+func (r feedGroupRegistry) checkType(slug string, typ FeedType) error {
+	if r == nil {
+		return nil
+	}
+	g, err := r.lookup(slug)
+	if err != nil {
+		return err
+	}
+	if g.Type != typ {
+		return fmt.Errorf("feed group %q is a %s feed group, not a %s one", slug, g.Type, typ)
+	}
+	return nil
+}
+
+// checkFollow checks that feeds of the source group can follow feeds of the
+// target group.
+func (r feedGroupRegistry) checkFollow(source, target string) error {
+	if r == nil {
+		return nil
+	}
+	sg, err := r.lookup(source)
+	if err != nil {
+		return err
+	}
+	if len(sg.FollowTargets) > 0 {
+		if !slices.Contains(sg.FollowTargets, target) {
+			return fmt.Errorf("feed group %q cannot follow feed group %q", source, target)
+		}
+		return nil
+	}
+	return r.checkType(target, FeedTypeFlat)
+}
+
+// checkRanking checks that the ranking method is configured for the group.
+func (r feedGroupRegistry) checkRanking(slug, ranking string) error {
+	if r == nil || ranking == "" {
+		return nil
+	}
+	g, err := r.lookup(slug)
+	if err != nil {
+		return err
+	}
+	if len(g.RankingMethods) > 0 && !slices.Contains(g.RankingMethods, ranking) {
+		return fmt.Errorf("feed group %q has no ranking method %q", slug, ranking)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+var testFeedGroups = []stream.FeedGroup{
+	{Slug: "user", Type: stream.FeedTypeFlat, RankingMethods: []string{"popularity"}},
+	{Slug: "timeline", Type: stream.FeedTypeFlat, FollowTargets: []string{"user"}},
+	{Slug: "aggregated", Type: stream.FeedTypeAggregated},
+	{Slug: "notification", Type: stream.FeedTypeNotification},
+}
+
+func TestFeedGroupsValidation(t *testing.T) {
+	testCases := []stream.FeedGroup{
+		{Slug: "us-er", Type: stream.FeedTypeFlat},
+		{Slug: "user", Type: "ranked"},
+		{Slug: "user", Type: stream.FeedTypeFlat, FollowTargets: []string{"missing"}},
+		{Slug: "user", Type: stream.FeedTypeFlat, FollowTargets: []string{"notification"}},
+	}
+	for _, tc := range testCases {
+		_, err := stream.New("key", "secret", stream.WithFeedGroups(tc, stream.FeedGroup{Slug: "notification", Type: stream.FeedTypeNotification}))
+		assert.Error(t, err, tc.Slug)
+	}
+}
+
+func TestFeedGroups(t *testing.T) {
+	requester := &mockRequester{}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(requester), stream.WithFeedGroups(testFeedGroups...))
+	require.NoError(t, err)
+	ctx := context.Background()
+
+	g, ok := client.FeedGroup("timeline")
+	require.True(t, ok)
+	assert.Equal(t, stream.FeedTypeFlat, g.Type)
+
+	_, err = client.FlatFeed("notification", "123")
+	assert.EqualError(t, err, `feed group "notification" is a notification feed group, not a flat one`)
+	_, err = client.AggregatedFeed("missing", "123")
+	assert.EqualError(t, err, `feed group "missing" is not registered`)
+	_, err = client.GenericFeed("missing:123")
+	assert.Error(t, err)
+
+	feed, err := client.GenericFeed("notification:123")
+	require.NoError(t, err)
+	assert.IsType(t, (*stream.NotificationFeed)(nil), feed)
+	feed, err = client.GenericFeed("aggregated:123")
+	require.NoError(t, err)
+	assert.IsType(t, (*stream.AggregatedFeed)(nil), feed)
+	feed, err = client.GenericFeed("user:123")
+	require.NoError(t, err)
+	user := feed.(*stream.FlatFeed)
+
+	timeline, err := client.FlatFeed("timeline", "123")
+	require.NoError(t, err)
+	_, err = timeline.Follow(ctx, user)
+	require.NoError(t, err)
+	_, err = user.Follow(ctx, timeline)
+	require.NoError(t, err)
+	other, err := client.FlatFeed("user", "456")
+	require.NoError(t, err)
+	_, err = timeline.Follow(ctx, other)
+	require.NoError(t, err)
+	_, err = timeline.Follow(ctx, timeline)
+	assert.EqualError(t, err, `feed group "timeline" cannot follow feed group "timeline"`)
+
+	err = client.FollowMany(ctx, []stream.FollowRelationship{
+		stream.NewFollowRelationship(timeline, user),
+		{Source: "timeline:123", Target: "notification:1"},
+	})
+	assert.Error(t, err)
+
+	_, err = user.GetActivitiesWithRanking(ctx, "popularity")
+	require.NoError(t, err)
+	requester.req = nil
+	_, err = user.GetActivitiesWithRanking(ctx, "recent")
+	assert.EqualError(t, err, `feed group "user" has no ranking method "recent"`)
+	assert.Nil(t, requester.req)
+}
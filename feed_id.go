@@ -0,0 +1,132 @@
+package stream
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+var slugRegex = regexp.MustCompile(`^[a-zA-Z0-9_]+$`)
+
+// FeedID identifies a feed by its slug (the feed group) and user ID, and is
+// represented as "slug:user_id". Feed targets stay strings in the requests
+// and responses: use FeedIDStrings to pass feed IDs as targets, and
+// Follower.Feed and Follower.Target to parse the followers and followings.
+type FeedID struct {
+	Slug   string
+	UserID string
+}
+
+// NewFeedID returns the FeedID with the given slug and user ID, validating
+// them.
+func NewFeedID(slug, userID string) (FeedID, error) {
+	id := FeedID{Slug: slug, UserID: userID}
+	if err := id.Validate(); err != nil {
+		return FeedID{}, err
+	}
+	return id, nil
+}
+
+// ParseFeedID parses and validates a feed ID in the "slug:user_id" form.
+func ParseFeedID(s string) (FeedID, error) {
+	slug, userID, ok := strings.Cut(s, feedSlugIDSeparator)
+	if !ok {
+		return FeedID{}, fmt.Errorf("invalid feed id %q: missing %q separator", s, feedSlugIDSeparator)
+	}
+	id, err := NewFeedID(slug, userID)
+	if err != nil {
+		return FeedID{}, fmt.Errorf("invalid feed id %q: %w", s, err)
+	}
+	return id, nil
+}
+
+// MustParseFeedID is like ParseFeedID but panics if the feed ID is invalid.
+func MustParseFeedID(s string) FeedID {
+	id, err := ParseFeedID(s)
+	if err != nil {
+		panic(err)
+	}
+	return id
+}
+
+// Validate checks that the slug contains only letters, digits and
+// underscores, and that the user ID contains only letters, digits,
+// underscores and dashes.
+func (id FeedID) Validate() error {
+	if !slugRegex.MatchString(id.Slug) {
+		return errInvalidSlug
+	}
+	if !userIDRegex.MatchString(id.UserID) {
+		return errInvalidUserID
+	}
+	return nil
+}
+
+// IsZero tells whether the FeedID is empty.
+func (id FeedID) IsZero() bool {
+	return id == FeedID{}
+}
+
+// String returns the feed ID as slug:user_id.
+func (id FeedID) String() string {
+	return id.Slug + feedSlugIDSeparator + id.UserID
+}
+
+// MarshalText encodes the FeedID as slug:user_id.
+func (id FeedID) MarshalText() ([]byte, error) {
+	return []byte(id.String()), nil
+}
+
+// UnmarshalText parses and validates a slug:user_id feed ID.
+func (id *FeedID) UnmarshalText(b []byte) error {
+	parsed, err := ParseFeedID(string(b))
+	if err != nil {
+		return err
+	}
+	*id = parsed
+	return nil
+}
+
+// MarshalJSON encodes the FeedID as a slug:user_id JSON string.
+func (id FeedID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(id.String())
+}
+
+// UnmarshalJSON decodes a slug:user_id JSON string.
+func (id *FeedID) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+	return id.UnmarshalText([]byte(s))
+}
+
+// FeedIDOf returns the FeedID of the given feed.
+func FeedIDOf(f Feed) FeedID {
+	return FeedID{Slug: f.Slug(), UserID: f.UserID()}
+}
+
+// FeedIDStrings returns the given feed IDs as strings, to be used where feed
+// targets are taken as strings and no FeedID variant exists, such as
+// ReactionKind.Add.
+func FeedIDStrings(ids ...FeedID) []string {
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = id.String()
+	}
+	return s
+}
+
+// ParseFeedIDs parses and validates the given feed IDs.
+func ParseFeedIDs(s ...string) ([]FeedID, error) {
+	ids := make([]FeedID, len(s))
+	for i := range s {
+		id, err := ParseFeedID(s[i])
+		if err != nil {
+			return nil, err
+		}
+		ids[i] = id
+	}
+	return ids, nil
+}
@@ -0,0 +1,130 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestParseFeedID(t *testing.T) {
+	testCases := []struct {
+		input       string
+		expected    stream.FeedID
+		shouldError bool
+	}{
+		{input: "user:123", expected: stream.FeedID{Slug: "user", UserID: "123"}},
+		{input: "timeline_aggregated:john-doe_1", expected: stream.FeedID{Slug: "timeline_aggregated", UserID: "john-doe_1"}},
+		{input: "user", shouldError: true},
+		{input: "user:", shouldError: true},
+		{input: ":123", shouldError: true},
+		{input: "us-er:123", shouldError: true},
+		{input: "user:1:2", shouldError: true},
+		{input: "user:a b", shouldError: true},
+	}
+	for _, tc := range testCases {
+		id, err := stream.ParseFeedID(tc.input)
+		if tc.shouldError {
+			assert.Error(t, err, tc.input)
+			continue
+		}
+		require.NoError(t, err, tc.input)
+		assert.Equal(t, tc.expected, id)
+		assert.Equal(t, tc.input, id.String())
+	}
+	assert.Panics(t, func() { stream.MustParseFeedID("invalid") })
+}
+
+func TestFeedIDJSON(t *testing.T) {
+	type payload struct {
+		Feed  stream.FeedID         `json:"feed"`
+		Feeds []stream.FeedID       `json:"feeds"`
+		Count map[stream.FeedID]int `json:"count"`
+	}
+	id := stream.MustParseFeedID("user:123")
+	b, err := json.Marshal(payload{Feed: id, Feeds: []stream.FeedID{id}, Count: map[stream.FeedID]int{id: 1}})
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"feed":"user:123","feeds":["user:123"],"count":{"user:123":1}}`, string(b))
+
+	var p payload
+	require.NoError(t, json.Unmarshal(b, &p))
+	assert.Equal(t, id, p.Feed)
+	assert.Equal(t, 1, p.Count[id])
+
+	assert.Error(t, json.Unmarshal([]byte(`{"feed":"user"}`), &p))
+	assert.Error(t, json.Unmarshal([]byte(`{"feed":1}`), &p))
+}
+
+func TestFeedIDHelpers(t *testing.T) {
+	client, _ := newClient(t)
+	id := stream.MustParseFeedID("user:123")
+
+	flat, err := client.FlatFeedFromID(id)
+	require.NoError(t, err)
+	assert.Equal(t, id, flat.FeedID())
+	aggregated, err := client.AggregatedFeedFromID(id)
+	require.NoError(t, err)
+	assert.Equal(t, "user:123", aggregated.ID())
+	_, err = client.NotificationFeedFromID(stream.FeedID{Slug: "user"})
+	require.Error(t, err)
+	_, err = client.FlatFeed("us-er", "123")
+	require.Error(t, err)
+	feed, err := client.GenericFeed("user:123")
+	require.NoError(t, err)
+	assert.Equal(t, id, stream.FeedIDOf(feed))
+
+	activity := stream.Activity{To: stream.FeedIDStrings(id, stream.MustParseFeedID("timeline:1"))}
+	assert.Equal(t, []string{"user:123", "timeline:1"}, activity.To)
+	ids, err := activity.ToFeedIDs()
+	require.NoError(t, err)
+	assert.Equal(t, []stream.FeedID{id, {Slug: "timeline", UserID: "1"}}, ids)
+
+	follower := stream.Follower{FeedID: "timeline:1", TargetID: "user:123"}
+	target, err := follower.Target()
+	require.NoError(t, err)
+	assert.Equal(t, id, target)
+
+	rel := stream.NewFollowRelationshipFromIDs(ids[1], id)
+	assert.Equal(t, stream.FollowRelationship{Source: "timeline:1", Target: "user:123"}, rel)
+	unrel := stream.NewUnfollowRelationshipFromIDs(ids[1], id, stream.WithUnfollowRelationshipKeepHistory())
+	assert.Equal(t, stream.UnfollowRelationship{Source: "timeline:1", Target: "user:123", KeepHistory: true}, unrel)
+}
+
+func TestFeedIDTargets(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	timeline := stream.MustParseFeedID("timeline:1")
+	notification := stream.MustParseFeedID("notification:1")
+
+	activity := stream.Activity{ForeignID: "bob:123", Time: getTime(time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)), To: []string{"user:1"}}
+	activity.AddTo(timeline, notification)
+	assert.Equal(t, []string{"user:1", "timeline:1", "notification:1"}, activity.To)
+
+	flat, err := client.FlatFeed("user", "123")
+	require.NoError(t, err)
+	_, err = flat.UpdateToTargets(ctx, activity, stream.WithToTargetsAddIDs(timeline), stream.WithToTargetsRemoveIDs(notification))
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/feed_targets/user/123/activity_to_targets/?api_key=key",
+		`{"foreign_id":"bob:123","time":"2024-05-01T12:00:00","added_targets":["timeline:1"],"removed_targets":["notification:1"]}`)
+	_, err = flat.UpdateToTargets(ctx, activity, stream.WithToTargetsNewIDs(timeline))
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/feed_targets/user/123/activity_to_targets/?api_key=key",
+		`{"foreign_id":"bob:123","time":"2024-05-01T12:00:00","new_targets":["timeline:1"]}`)
+
+	reaction := stream.AddReactionRequestObject{Kind: "like", ActivityID: "a1", UserID: "bob"}
+	reaction.AddTargetFeeds(timeline)
+	assert.Equal(t, []string{"timeline:1"}, reaction.TargetFeeds)
+	update := stream.ReactionUpdate{ID: "r1"}
+	update.AddTargetFeeds(timeline, notification)
+	assert.Equal(t, []string{"timeline:1", "notification:1"}, update.TargetFeeds)
+
+	_, err = client.Reactions().UpdateWithFeedIDs(ctx, "r1", nil, []stream.FeedID{timeline})
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodPut, "https://api.stream-io-api.com/api/v1.0/reaction/r1/?api_key=key", `{"data":null,"target_feeds":["timeline:1"]}`)
+}
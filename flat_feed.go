@@ -2,7 +2,6 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // FlatFeed is a Stream flat feed.
@@ -13,12 +12,8 @@ type FlatFeed struct {
 // GetActivities returns the activities for the given FlatFeed, filtering
 // results with the provided GetActivitiesOption parameters.
 func (f *FlatFeed) GetActivities(ctx context.Context, opts ...GetActivitiesOption) (*FlatFeedResponse, error) {
-	body, err := f.client.getActivities(ctx, f, opts...)
-	if err != nil {
-		return nil, err
-	}
 	var resp FlatFeedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := f.client.getActivities(ctx, f, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -48,12 +43,8 @@ func (f *FlatFeed) GetFollowers(ctx context.Context, opts ...FollowersOption) (*
 // GetEnrichedActivities returns the enriched activities for the given FlatFeed, filtering
 // results with the provided GetActivitiesOption parameters.
 func (f *FlatFeed) GetEnrichedActivities(ctx context.Context, opts ...GetActivitiesOption) (*EnrichedFlatFeedResponse, error) {
-	body, err := f.client.getEnrichedActivities(ctx, f, opts...)
-	if err != nil {
-		return nil, err
-	}
 	var resp EnrichedFlatFeedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := f.client.getEnrichedActivities(ctx, f, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
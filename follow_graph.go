@@ -0,0 +1,267 @@
+package stream
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+)
+
+const (
+	maxFollowsLimit           = 500
+	maxFollowsOffset          = 400
+	defaultImportFollowsChunk = 1000
+)
+
+// ErrTooManyFollows is returned when reading the followers or followings of a
+// feed having more of them than the API can page through: it caps the offset
+// to 400 and the limit to 500.
+var ErrTooManyFollows = fmt.Errorf("feed has more than %d follows, the most the API can page through", maxFollowsOffset+maxFollowsLimit)
+
+// followsPager reads the follows of a feed in one direction.
+type followsPager struct {
+	// fetch reads a page of follows.
+	fetch func(ctx context.Context, limit, offset int) ([]Follower, error)
+	// count returns the number of follows, read when the pages reach the
+	// caps of the API since they can't tell whether more follows exist.
+	count func(ctx context.Context) (int, error)
+}
+
+// pageFollows calls fn with every follow relationship read by the pager,
+// within the offset and limit caps of the API.
+func pageFollows(ctx context.Context, pager followsPager, fn func(Follower) error) error {
+	for offset := 0; ; {
+		limit := maxFollowsLimit
+		if offset < maxFollowsOffset {
+			limit = min(limit, maxFollowsOffset-offset)
+		}
+		results, err := pager.fetch(ctx, limit, offset)
+		if err != nil {
+			return err
+		}
+		for _, f := range results {
+			if err := fn(f); err != nil {
+				return err
+			}
+		}
+		if len(results) < limit {
+			return nil
+		}
+		if offset >= maxFollowsOffset {
+			count, err := pager.count(ctx)
+			if err != nil {
+				return err
+			}
+			if count > maxFollowsOffset+maxFollowsLimit {
+				return ErrTooManyFollows
+			}
+			return nil
+		}
+		offset += limit
+	}
+}
+
+func (c *Client) followingPager(feed Feed) followsPager {
+	return followsPager{
+		fetch: func(ctx context.Context, limit, offset int) ([]Follower, error) {
+			resp, err := c.getFollowing(ctx, feed, WithFollowingLimit(limit), WithFollowingOffset(offset))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Results, nil
+		},
+		count: func(ctx context.Context) (int, error) {
+			resp, err := c.followStats(ctx, feed)
+			if err != nil {
+				return 0, err
+			}
+			return resp.Following.Count, nil
+		},
+	}
+}
+
+func (c *Client) followersPager(feed Feed) followsPager {
+	return followsPager{
+		fetch: func(ctx context.Context, limit, offset int) ([]Follower, error) {
+			resp, err := c.getFollowers(ctx, feed, WithFollowersLimit(limit), WithFollowersOffset(offset))
+			if err != nil {
+				return nil, err
+			}
+			return resp.Results, nil
+		},
+		count: func(ctx context.Context) (int, error) {
+			resp, err := c.followStats(ctx, feed)
+			if err != nil {
+				return 0, err
+			}
+			return resp.Followers.Count, nil
+		},
+	}
+}
+
+// ExportFollowsOption configures ExportFollows.
+type ExportFollowsOption func(*exportFollowsOptions)
+
+type exportFollowsOptions struct {
+	following bool
+	followers bool
+}
+
+// WithExportFollowing exports the feeds followed by the given feeds. It's the
+// default when no direction is given.
+func WithExportFollowing() ExportFollowsOption {
+	return func(o *exportFollowsOptions) {
+		o.following = true
+	}
+}
+
+// WithExportFollowers exports the feeds following the given feeds.
+func WithExportFollowers() ExportFollowsOption {
+	return func(o *exportFollowsOptions) {
+		o.followers = true
+	}
+}
+
+// ExportFollows writes the follow relationships of the given feeds to w as
+// JSON lines of FollowRelationship, paginating through their followed feeds
+// and/or followers. Relationships are written once even if found in both
+// directions. It returns the number of written relationships, and fails with
+// ErrTooManyFollows on feeds having more follows than the API can page through.
+func (c *Client) ExportFollows(ctx context.Context, w io.Writer, feeds []FeedID, opts ...ExportFollowsOption) (int, error) {
+	o := exportFollowsOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if !o.followers {
+		o.following = true
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+	seen := make(map[FollowRelationship]bool)
+	n := 0
+	write := func(f Follower) error {
+		r := FollowRelationship{Source: f.FeedID, Target: f.TargetID}
+		if seen[r] {
+			return nil
+		}
+		seen[r] = true
+		n++
+		return enc.Encode(r)
+	}
+	for _, id := range feeds {
+		feed, err := newFeed(id.Slug, id.UserID, c)
+		if err != nil {
+			return n, err
+		}
+		if o.following {
+			if err := pageFollows(ctx, c.followingPager(feed), write); err != nil {
+				return n, fmt.Errorf("cannot export follows of %s: %w", id, err)
+			}
+		}
+		if o.followers {
+			if err := pageFollows(ctx, c.followersPager(feed), write); err != nil {
+				return n, fmt.Errorf("cannot export followers of %s: %w", id, err)
+			}
+		}
+	}
+	return n, bw.Flush()
+}
+
+// ImportFollowsOption configures ImportFollows.
+type ImportFollowsOption func(*importFollowsOptions)
+
+type importFollowsOptions struct {
+	activityCopyLimit *int
+	chunkSize         int
+	offset            int
+	progress          func(offset int)
+}
+
+// WithImportActivityCopyLimit sets how many activities are copied from the
+// followed feeds, for all the imported relationships.
+func WithImportActivityCopyLimit(limit int) ImportFollowsOption {
+	return func(o *importFollowsOptions) {
+		o.activityCopyLimit = &limit
+	}
+}
+
+// WithImportFollowsChunkSize sets how many relationships are created per
+// FollowMany call, defaulting to 1000.
+func WithImportFollowsChunkSize(size int) ImportFollowsOption {
+	return func(o *importFollowsOptions) {
+		o.chunkSize = size
+	}
+}
+
+// WithImportFollowsOffset skips the given number of relationships, to resume
+// an interrupted import.
+func WithImportFollowsOffset(offset int) ImportFollowsOption {
+	return func(o *importFollowsOptions) {
+		o.offset = offset
+	}
+}
+
+// WithImportFollowsProgress sets the function called after every imported
+// chunk with the number of relationships read so far, including the skipped
+// ones. It's the offset to resume the import from.
+func WithImportFollowsProgress(fn func(offset int)) ImportFollowsOption {
+	return func(o *importFollowsOptions) {
+		o.progress = fn
+	}
+}
+
+// ImportFollows reads follow relationships as written by ExportFollows from r
+// and creates them with FollowMany, in chunks and in order. It returns the
+// offset reached, which is the number of relationships read before any
+// failing chunk, to be passed to WithImportFollowsOffset to resume the import.
+func (c *Client) ImportFollows(ctx context.Context, r io.Reader, opts ...ImportFollowsOption) (int, error) {
+	o := importFollowsOptions{chunkSize: defaultImportFollowsChunk}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.chunkSize <= 0 || o.chunkSize > maxFollowManyRelationships {
+		o.chunkSize = maxFollowManyRelationships
+	}
+
+	dec := json.NewDecoder(r)
+	offset := 0
+	chunk := make([]FollowRelationship, 0, o.chunkSize)
+	flush := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if err := c.FollowMany(ctx, chunk); err != nil {
+			return err
+		}
+		offset += len(chunk)
+		chunk = chunk[:0]
+		if o.progress != nil {
+			o.progress(offset)
+		}
+		return nil
+	}
+	for line := 0; ; line++ {
+		var rel FollowRelationship
+		if err := dec.Decode(&rel); err == io.EOF {
+			break
+		} else if err != nil {
+			return offset, fmt.Errorf("cannot decode relationship %d: %w", line, err)
+		}
+		if line < o.offset {
+			offset++
+			continue
+		}
+		if o.activityCopyLimit != nil {
+			rel.ActivityCopyLimit = o.activityCopyLimit
+		}
+		chunk = append(chunk, rel)
+		if len(chunk) == o.chunkSize {
+			if err := flush(); err != nil {
+				return offset, err
+			}
+		}
+	}
+	return offset, flush()
+}
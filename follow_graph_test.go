@@ -0,0 +1,107 @@
+package stream_test
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestExportFollows(t *testing.T) {
+	graph := &followGraph{follows: map[string]map[string]bool{
+		"timeline:1": {"user:1": true, "user:2": true},
+		"timeline:2": {"user:1": true},
+	}}
+	for i := 0; i < 510; i++ {
+		graph.follows["timeline:1"][fmt.Sprint("page:", i)] = true
+	}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(graph))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	n, err := client.ExportFollows(context.Background(), &buf, []stream.FeedID{stream.MustParseFeedID("timeline:1")})
+	require.NoError(t, err)
+	assert.Equal(t, 512, n)
+	assert.Equal(t, 512, strings.Count(buf.String(), "\n"))
+
+	for i := 510; i < 900; i++ {
+		graph.follows["timeline:1"][fmt.Sprint("page:", i)] = true
+	}
+	_, err = client.ExportFollows(context.Background(), &buf, []stream.FeedID{stream.MustParseFeedID("timeline:1")})
+	assert.ErrorIs(t, err, stream.ErrTooManyFollows)
+	// with exactly as many follows as the API can page through, the count
+	// tells that the last full page is the end
+	for i := 0; i < 2; i++ {
+		delete(graph.follows["timeline:1"], fmt.Sprint("page:", i))
+	}
+	buf.Reset()
+	graph.stats = 0
+	n, err = client.ExportFollows(context.Background(), &buf, []stream.FeedID{stream.MustParseFeedID("timeline:1")})
+	require.NoError(t, err)
+	assert.Equal(t, 900, n)
+	assert.Equal(t, 1, graph.stats)
+	delete(graph.follows["timeline:1"], "page:2")
+	graph.stats = 0
+	buf.Reset()
+	n, err = client.ExportFollows(context.Background(), &buf, []stream.FeedID{stream.MustParseFeedID("timeline:1")})
+	require.NoError(t, err)
+	assert.Equal(t, 899, n)
+	assert.Zero(t, graph.stats)
+
+	buf.Reset()
+	n, err = client.ExportFollows(context.Background(), &buf,
+		[]stream.FeedID{stream.MustParseFeedID("timeline:2"), stream.MustParseFeedID("user:1")},
+		stream.WithExportFollowing(), stream.WithExportFollowers())
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, `{"source":"timeline:2","target":"user:1"}
+{"source":"timeline:1","target":"user:1"}
+`, buf.String())
+}
+
+func TestImportFollows(t *testing.T) {
+	var input strings.Builder
+	for i := 0; i < 25; i++ {
+		fmt.Fprintf(&input, `{"source":"timeline:1","target":"user:%d"}`+"\n", i)
+	}
+	graph := &followGraph{follows: map[string]map[string]bool{}, failImport: "user:20"}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(graph))
+	require.NoError(t, err)
+
+	var progress []int
+	offset, err := client.ImportFollows(context.Background(), strings.NewReader(input.String()),
+		stream.WithImportFollowsChunkSize(10),
+		stream.WithImportActivityCopyLimit(5),
+		stream.WithImportFollowsProgress(func(offset int) { progress = append(progress, offset) }),
+	)
+	require.Error(t, err)
+	assert.Equal(t, 20, offset)
+	assert.Equal(t, []int{10, 20}, progress)
+	require.NotNil(t, graph.imported[0].ActivityCopyLimit)
+	assert.Equal(t, 5, *graph.imported[0].ActivityCopyLimit)
+
+	graph.failImport = ""
+	graph.imported = nil
+	progress = nil
+	offset, err = client.ImportFollows(context.Background(), strings.NewReader(input.String()),
+		stream.WithImportFollowsChunkSize(10),
+		stream.WithImportFollowsOffset(offset),
+		stream.WithImportFollowsProgress(func(offset int) { progress = append(progress, offset) }),
+	)
+	require.NoError(t, err)
+	assert.Equal(t, 25, offset)
+	assert.Equal(t, []int{25}, progress)
+	require.Len(t, graph.imported, 5)
+	assert.Equal(t, "user:20", graph.imported[0].Target)
+	assert.Nil(t, graph.imported[0].ActivityCopyLimit)
+	assert.Len(t, graph.following("timeline:1"), 25)
+
+	_, err = client.ImportFollows(context.Background(), strings.NewReader("not json"))
+	assert.Error(t, err)
+}
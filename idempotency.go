@@ -0,0 +1,152 @@
+package stream
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+)
+
+// ForeignIDGenerator returns the foreign ID of an activity lacking one. It
+// must be deterministic, so that retries of the same write get the same
+// foreign ID.
+type ForeignIDGenerator func(Activity) string
+
+// ContentForeignID is a ForeignIDGenerator deriving the foreign ID from a hash
+// of the activity content, excluding its time.
+func ContentForeignID(a Activity) string {
+	a.ID, a.ForeignID, a.Time = "", "", Time{}
+	b, _ := json.Marshal(a)
+	sum := sha256.Sum256(b)
+	return "sha256:" + hex.EncodeToString(sum[:16])
+}
+
+// WithIdempotentWrites makes activity additions idempotent: activities
+// missing a foreign ID get one from the given generator (ContentForeignID if
+// nil), and activities missing a time get the current one. When adding
+// activities fails in a way that leaves unknown whether they were stored,
+// such as a connection error or a server error, the client looks them up by
+// foreign ID and time before retrying, according to the RetryPolicy, so that
+// they're stored exactly once. The time is filled in once per call, so the
+// retries made by the caller across separate calls must pass an explicit
+// time to target the same activities.
+func WithIdempotentWrites(generator ForeignIDGenerator) ClientOption {
+	return func(c *Client) {
+		if generator == nil {
+			generator = ContentForeignID
+		}
+		c.foreignIDGenerator = generator
+	}
+}
+
+// prepareIdempotent fills the foreign ID and time of the activities, if
+// missing and if idempotent writes are enabled. It's called once per call,
+// before the first attempt, so that all the attempts of the call target the
+// same foreign ID and time.
+func (c *Client) prepareIdempotent(activities ...Activity) []Activity {
+	if c.foreignIDGenerator == nil {
+		return activities
+	}
+	prepared := make([]Activity, len(activities))
+	now := time.Now()
+	for i, a := range activities {
+		prepared[i] = identifyActivity(a, c.foreignIDGenerator, now)
+	}
+	return prepared
+}
+
+// identifyActivity fills the foreign ID and time of the activity, if missing,
+// so that it's identified by them.
+func identifyActivity(a Activity, generator ForeignIDGenerator, now time.Time) Activity {
+	if a.ForeignID == "" {
+		a.ForeignID = generator(a)
+	}
+	if a.Time.IsZero() {
+		a.Time = Time{Time: now.UTC().Truncate(time.Microsecond)}
+	}
+	return a
+}
+
+func foreignIDTimeKey(foreignID string, t Time) string {
+	return foreignID + "|" + t.UTC().Format(TimeLayout)
+}
+
+// addIdempotent adds the prepared activities using add, retrying according to the
+// RetryPolicy after looking up the activities stored by failed attempts.
+// It returns the stored activities, in the order of the given ones.
+func (c *Client) addIdempotent(ctx context.Context, activities []Activity, add func(context.Context, []Activity) ([]Activity, error)) ([]Activity, error) {
+	opts := callOptionsFromContext(ctx)
+	policy := c.retryPolicy
+	if opts.retryPolicy != nil {
+		policy = *opts.retryPolicy
+	}
+	if opts.timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.timeout)
+		defer cancel()
+	}
+	// attempts are retried here, after checking what was stored
+	ctx = WithCallOptions(ctx, WithCallRetryPolicy(RetryPolicy{}), WithCallTimeout(0))
+
+	stored := make(map[string]Activity, len(activities))
+	pending := activities
+	for attempt := 0; ; attempt++ {
+		added, err := add(ctx, pending)
+		if err == nil {
+			for i, a := range added {
+				if i < len(pending) {
+					stored[foreignIDTimeKey(pending[i].ForeignID, pending[i].Time)] = a
+				}
+			}
+			break
+		}
+		if !isRetryable(ctx, err) {
+			return nil, err
+		}
+		pending = c.unstoredActivities(ctx, pending, stored)
+		if len(pending) == 0 {
+			break
+		}
+		if attempt >= policy.MaxRetries {
+			return nil, err
+		}
+		if err := policy.wait(ctx, attempt); err != nil {
+			return nil, err
+		}
+	}
+
+	result := make([]Activity, len(activities))
+	for i, a := range activities {
+		if s, ok := stored[foreignIDTimeKey(a.ForeignID, a.Time)]; ok {
+			result[i] = s
+		} else {
+			result[i] = a
+		}
+	}
+	return result, nil
+}
+
+// unstoredActivities looks up the given activities by foreign ID and time,
+// recording the found ones in stored and returning the missing ones. If the
+// lookup fails, all the activities are considered missing.
+func (c *Client) unstoredActivities(ctx context.Context, activities []Activity, stored map[string]Activity) []Activity {
+	pairs := make([]ForeignIDTimePair, len(activities))
+	for i, a := range activities {
+		pairs[i] = NewForeignIDTimePair(a.ForeignID, a.Time)
+	}
+	resp, err := c.GetActivitiesByForeignID(ctx, pairs...)
+	if err != nil {
+		return activities
+	}
+	for _, a := range resp.Results {
+		stored[foreignIDTimeKey(a.ForeignID, a.Time)] = a
+	}
+	var missing []Activity
+	for _, a := range activities {
+		if _, ok := stored[foreignIDTimeKey(a.ForeignID, a.Time)]; !ok {
+			missing = append(missing, a)
+		}
+	}
+	return missing
+}
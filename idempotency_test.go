@@ -0,0 +1,133 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestIdempotentWrites(t *testing.T) {
+	// the backend stores the added activities, failing the first failAdds
+	// additions after storing them
+	var (
+		stored               []stream.Activity
+		adds, lookups        int
+		failAdds, failStatus = 1, 0
+	)
+	client, _ := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		if r.method != http.MethodPost {
+			lookups++
+			resp, _ := json.Marshal(map[string]any{"results": stored})
+			return jsonResponse(http.StatusOK, string(resp)), nil
+		}
+		adds++
+		body := r.body
+		var batch struct {
+			Activities []stream.Activity `json:"activities"`
+		}
+		if r.decode(&batch) == nil && batch.Activities != nil {
+			stored = append(stored, batch.Activities...)
+		} else {
+			var single stream.Activity
+			_ = r.decode(&single)
+			stored = append(stored, single)
+			body, _ = json.Marshal(single)
+		}
+		if adds <= failAdds {
+			if failStatus != 0 {
+				return errorResponse(failStatus, "oops"), nil
+			}
+			return nil, errors.New("connection reset")
+		}
+		return jsonResponse(http.StatusCreated, string(body)), nil
+	},
+		stream.WithIdempotentWrites(nil),
+		stream.WithRetryPolicy(stream.RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}),
+	)
+	feed, err := client.FlatFeed("user", "123")
+	require.NoError(t, err)
+	now := stream.Time{Time: time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)}
+	activity := stream.Activity{Actor: "bob", Verb: "like", Object: "cake", Time: now}
+
+	resp, err := feed.AddActivity(context.Background(), activity)
+	require.NoError(t, err)
+	assert.Equal(t, 1, adds)
+	assert.Equal(t, 1, lookups)
+	require.Len(t, stored, 1)
+	assert.Equal(t, stream.ContentForeignID(activity), resp.ForeignID)
+	assert.Equal(t, now, resp.Time)
+	assert.Equal(t, "cake", resp.Object)
+
+	// a retry by the caller targets the same activity
+	resp, err = feed.AddActivity(context.Background(), activity)
+	require.NoError(t, err)
+	assert.Equal(t, stored[0].ForeignID, stored[1].ForeignID)
+	assert.Equal(t, stored[0].Time, stored[1].Time)
+
+	// activities missing a time get one, shared by all the attempts of the call
+	stored = nil
+	adds, lookups, failAdds = 0, 0, 1
+	resp, err = feed.AddActivity(context.Background(), stream.Activity{Actor: "bob", Verb: "like", Object: "pie"})
+	require.NoError(t, err)
+	assert.Equal(t, 1, adds)
+	assert.Equal(t, 1, lookups)
+	require.Len(t, stored, 1)
+	assert.Equal(t, time.UTC, resp.Time.Location())
+	assert.False(t, resp.Time.IsZero())
+	assert.Equal(t, stored[0].Time, resp.Time)
+
+	// the lookup doesn't find the activities stored by other attempts
+	stored = nil
+	adds, lookups, failAdds, failStatus = 0, 0, 1, http.StatusServiceUnavailable
+	batch, err := feed.AddActivities(context.Background(), activity, stream.Activity{Actor: "bob", Verb: "eat", Object: "cake", ForeignID: "eat:1", Time: now})
+	require.NoError(t, err)
+	assert.Equal(t, 1, adds)
+	require.Len(t, batch.Activities, 2)
+	assert.Equal(t, "eat:1", batch.Activities[1].ForeignID)
+	assert.Equal(t, batch.Activities[0].Time, batch.Activities[1].Time)
+}
+
+func TestIdempotentWritesRetry(t *testing.T) {
+	var (
+		adds    int
+		lookups int
+	)
+	client, _ := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		if r.method == http.MethodGet {
+			lookups++
+			return jsonResponse(http.StatusOK, `{"results":[]}`), nil
+		}
+		adds++
+		if adds == 1 {
+			return nil, errors.New("connection reset")
+		}
+		return jsonResponse(http.StatusCreated, string(r.body)), nil
+	},
+		stream.WithIdempotentWrites(func(a stream.Activity) string { return "fixed" }),
+		stream.WithRetryPolicy(stream.RetryPolicy{MaxRetries: 1, Backoff: time.Millisecond}),
+	)
+	feed, err := client.FlatFeed("user", "123")
+	require.NoError(t, err)
+
+	resp, err := feed.AddActivity(context.Background(), stream.Activity{Actor: "bob", Verb: "like", Object: "cake", Time: stream.Time{Time: time.Now().UTC()}})
+	require.NoError(t, err)
+	assert.Equal(t, 2, adds)
+	assert.Equal(t, 1, lookups)
+	assert.Equal(t, "fixed", resp.ForeignID)
+
+	// without retries, the error is returned once the lookup misses the activity
+	adds = 0
+	ctx := stream.WithCallOptions(context.Background(), stream.WithCallRetryPolicy(stream.RetryPolicy{}))
+	_, err = feed.AddActivity(ctx, stream.Activity{Actor: "bob", Verb: "like", Object: "cake", Time: stream.Time{Time: time.Now().UTC()}})
+	require.Error(t, err)
+	assert.Equal(t, 1, adds)
+	assert.Equal(t, 2, lookups)
+}
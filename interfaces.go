@@ -0,0 +1,270 @@
+package stream
+
+import (
+	"context"
+	"io"
+	"time"
+)
+
+var (
+	_ FlatFeedInterface              = (*FlatFeed)(nil)
+	_ AggregatedFeedInterface        = (*AggregatedFeed)(nil)
+	_ NotificationFeedInterface      = (*NotificationFeed)(nil)
+	_ ReactionsClientInterface       = (*ReactionsClient)(nil)
+	_ CollectionsClientInterface     = (*CollectionsClient)(nil)
+	_ UsersClientInterface           = (*UsersClient)(nil)
+	_ ModerationClientInterface      = (*ModerationClient)(nil)
+	_ AnalyticsClientInterface       = (*AnalyticsClient)(nil)
+	_ PersonalizationClientInterface = (*PersonalizationClient)(nil)
+	_ AuditLogsClientInterface       = (*AuditLogsClient)(nil)
+	_ ClientInterface                = clientInterface{}
+)
+
+// FlatFeedInterface is the method set of a FlatFeed.
+type FlatFeedInterface interface {
+	Feed
+	FeedID() FeedID
+	GetActivities(context.Context, ...GetActivitiesOption) (*FlatFeedResponse, error)
+	GetNextPageActivities(context.Context, *FlatFeedResponse) (*FlatFeedResponse, error)
+	GetActivitiesWithRanking(context.Context, string, ...GetActivitiesOption) (*FlatFeedResponse, error)
+	GetEnrichedActivities(context.Context, ...GetActivitiesOption) (*EnrichedFlatFeedResponse, error)
+	GetNextPageEnrichedActivities(context.Context, *EnrichedFlatFeedResponse) (*EnrichedFlatFeedResponse, error)
+	GetEnrichedActivitiesWithRanking(context.Context, string, ...GetActivitiesOption) (*EnrichedFlatFeedResponse, error)
+	GetFollowers(context.Context, ...FollowersOption) (*FollowersResponse, error)
+	FollowStats(context.Context, ...FollowStatOption) (*FollowStatResponse, error)
+}
+
+// AggregatedFeedInterface is the method set of an AggregatedFeed.
+type AggregatedFeedInterface interface {
+	Feed
+	FeedID() FeedID
+	GetActivities(context.Context, ...GetActivitiesOption) (*AggregatedFeedResponse, error)
+	GetNextPageActivities(context.Context, *AggregatedFeedResponse) (*AggregatedFeedResponse, error)
+	GetActivitiesWithRanking(context.Context, string, ...GetActivitiesOption) (*AggregatedFeedResponse, error)
+	GetEnrichedActivities(context.Context, ...GetActivitiesOption) (*EnrichedAggregatedFeedResponse, error)
+	GetNextPageEnrichedActivities(context.Context, *EnrichedAggregatedFeedResponse) (*EnrichedAggregatedFeedResponse, error)
+	GetEnrichedActivitiesWithRanking(context.Context, string, ...GetActivitiesOption) (*EnrichedAggregatedFeedResponse, error)
+}
+
+// NotificationFeedInterface is the method set of a NotificationFeed.
+type NotificationFeedInterface interface {
+	Feed
+	FeedID() FeedID
+	GetActivities(context.Context, ...GetActivitiesOption) (*NotificationFeedResponse, error)
+	GetNextPageActivities(context.Context, *NotificationFeedResponse) (*NotificationFeedResponse, error)
+	GetEnrichedActivities(context.Context, ...GetActivitiesOption) (*EnrichedNotificationFeedResponse, error)
+	GetNextPageEnrichedActivities(context.Context, *EnrichedNotificationFeedResponse) (*EnrichedNotificationFeedResponse, error)
+}
+
+// ReactionsClientInterface is the method set of a ReactionsClient.
+type ReactionsClientInterface interface {
+	Add(context.Context, AddReactionRequestObject) (*ReactionResponse, error)
+	AddChild(context.Context, string, AddReactionRequestObject) (*ReactionResponse, error)
+	Update(context.Context, string, map[string]any, []string) (*ReactionResponse, error)
+	UpdateWithFeedIDs(context.Context, string, map[string]any, []FeedID) (*ReactionResponse, error)
+	Get(context.Context, string) (*ReactionResponse, error)
+	Delete(context.Context, string, ...ReactionOption) (*ReactionResponse, error)
+	SoftDelete(context.Context, string, ...ReactionOption) error
+	Restore(context.Context, string, ...ReactionOption) error
+	Filter(context.Context, FilterReactionsAttribute, ...FilterReactionsOption) (*FilterReactionResponse, error)
+	GetNextPageFilteredReactions(context.Context, *FilterReactionResponse) (*FilterReactionResponse, error)
+	GetThread(context.Context, string, int, ...ThreadOption) (*ReactionThread, error)
+	AddMany(context.Context, []AddReactionRequestObject, ...BatchOption) (*BatchResult, error)
+	UpdateMany(context.Context, []ReactionUpdate, ...BatchOption) (*BatchResult, error)
+	DeleteMany(context.Context, []string, ...BatchOption) (*BatchResult, error)
+	SoftDeleteMany(context.Context, []string, ...BatchOption) (*BatchResult, error)
+	RestoreMany(context.Context, []string, ...BatchOption) (*BatchResult, error)
+}
+
+// CollectionsClientInterface is the method set of a CollectionsClient.
+type CollectionsClientInterface interface {
+	Upsert(context.Context, string, ...CollectionObject) (*BaseResponse, error)
+	Select(context.Context, string, ...string) (*GetCollectionResponse, error)
+	DeleteMany(context.Context, string, ...string) (*BaseResponse, error)
+	Add(context.Context, string, CollectionObject, ...AddObjectOption) (*CollectionObjectResponse, error)
+	Get(context.Context, string, string) (*CollectionObjectResponse, error)
+	Update(context.Context, string, string, map[string]any) (*CollectionObjectResponse, error)
+	Delete(context.Context, string, string) (*BaseResponse, error)
+	CreateReference(string, string) string
+}
+
+// UsersClientInterface is the method set of a UsersClient.
+type UsersClientInterface interface {
+	Add(context.Context, User, bool) (*UserResponse, error)
+	Update(context.Context, string, map[string]any) (*UserResponse, error)
+	Get(context.Context, string) (*UserResponse, error)
+	Delete(context.Context, string) (*BaseResponse, error)
+	CreateReference(string) string
+}
+
+// ModerationClientInterface is the method set of a ModerationClient.
+type ModerationClientInterface interface {
+	FlagActivity(context.Context, string, string, string, ...FlagOption) error
+	FlagReaction(context.Context, string, string, string, ...FlagOption) error
+	FlagUser(context.Context, string, string, string, ...FlagOption) error
+	UpdateActivityModerationStatus(context.Context, string, string, string, string, string) error
+	UpdateReactionModerationStatus(context.Context, string, string, string, string, string) error
+	UpdateActivityStatus(context.Context, string, ModerationStatusUpdate) error
+	UpdateReactionStatus(context.Context, string, ModerationStatusUpdate) error
+	UpdateStatusBatch(context.Context, UpdateStatusBatchRequest) (*UpdateStatusBatchResponse, error)
+	InvalidateUserCache(context.Context, string) error
+	QueryFlags(context.Context, QueryFlagsFilters, QueryFlagsPager) (*QueryFlagsResponse, error)
+	GetFlag(context.Context, string) (*FlagResponse, error)
+	ResolveFlags(context.Context, []Flag) (*ResolvedFlags, error)
+	UnflagActivity(context.Context, string, string) error
+	UnflagReaction(context.Context, string, string) error
+	UnflagUser(context.Context, string, string) error
+	BanUser(context.Context, string, string, string, time.Duration) error
+	UnbanUser(context.Context, string, string) error
+}
+
+// AnalyticsClientInterface is the method set of an AnalyticsClient.
+type AnalyticsClientInterface interface {
+	TrackEngagement(context.Context, ...EngagementEvent) (*BaseResponse, error)
+	TrackImpression(context.Context, ImpressionEventsData) (*BaseResponse, error)
+	RedirectAndTrack(string, ...map[string]any) (string, error)
+}
+
+// PersonalizationClientInterface is the method set of a PersonalizationClient.
+type PersonalizationClientInterface interface {
+	Get(context.Context, string, map[string]any) (*PersonalizationResponse, error)
+	Post(context.Context, string, map[string]any, map[string]any) (*PersonalizationResponse, error)
+	Delete(context.Context, string, map[string]any) (*PersonalizationResponse, error)
+}
+
+// AuditLogsClientInterface is the method set of an AuditLogsClient.
+type AuditLogsClientInterface interface {
+	QueryAuditLogs(context.Context, QueryAuditLogsFilters, QueryAuditLogsPager) (*QueryAuditLogsResponse, error)
+	ExportAuditLogs(context.Context, io.Writer, QueryAuditLogsFilters, ...ExportAuditLogsOption) (int, error)
+}
+
+// ClientInterface is the method set of a Client, with feeds and sub-clients
+// returned as interfaces so that they can be replaced in tests. Use
+// Client.Interface to obtain the ClientInterface of a Client.
+type ClientInterface interface {
+	WithTimeout(time.Duration) ClientInterface
+	FlatFeed(string, string) (FlatFeedInterface, error)
+	AggregatedFeed(string, string) (AggregatedFeedInterface, error)
+	NotificationFeed(string, string) (NotificationFeedInterface, error)
+	FlatFeedFromID(FeedID) (FlatFeedInterface, error)
+	AggregatedFeedFromID(FeedID) (AggregatedFeedInterface, error)
+	NotificationFeedFromID(FeedID) (NotificationFeedInterface, error)
+	GenericFeed(string) (Feed, error)
+	FeedGroup(string) (FeedGroup, bool)
+	AddToMany(context.Context, Activity, ...Feed) error
+	FollowMany(context.Context, []FollowRelationship, ...FollowManyOption) error
+	UnfollowMany(context.Context, []UnfollowRelationship) error
+	Analytics() AnalyticsClientInterface
+	Collections() CollectionsClientInterface
+	Users() UsersClientInterface
+	Reactions() ReactionsClientInterface
+	Moderation() ModerationClientInterface
+	AuditLogs() AuditLogsClientInterface
+	Personalization() PersonalizationClientInterface
+	GetActivitiesByID(context.Context, ...string) (*GetActivitiesResponse, error)
+	GetActivitiesByForeignID(context.Context, ...ForeignIDTimePair) (*GetActivitiesResponse, error)
+	GetEnrichedActivitiesByID(context.Context, []string, ...GetActivitiesOption) (*GetEnrichedActivitiesResponse, error)
+	GetEnrichedActivitiesByForeignID(context.Context, []ForeignIDTimePair, ...GetActivitiesOption) (*GetEnrichedActivitiesResponse, error)
+	GetReactions(context.Context, []string, ...GetReactionsOption) (*GetReactionsByIDsResponse, error)
+	UpdateActivities(context.Context, ...Activity) (*BaseResponse, error)
+	PartialUpdateActivities(context.Context, ...UpdateActivityRequest) (*UpdateActivitiesResponse, error)
+	UpdateActivityByID(context.Context, string, map[string]any, []string) (*UpdateActivityResponse, error)
+	UpdateActivityByForeignID(context.Context, string, Time, map[string]any, []string) (*UpdateActivityResponse, error)
+	RemoveActivitiesByID(context.Context, Feed, []string, ...BatchOption) (*BatchResult, error)
+	RemoveActivitiesByForeignID(context.Context, Feed, []string, ...BatchOption) (*BatchResult, error)
+	BulkUpdateActivities(context.Context, []Activity, ...BatchOption) (*BatchResult, error)
+	BulkPartialUpdateActivities(context.Context, []UpdateActivityRequest, ...BatchOption) (*BatchResult, error)
+	AddToManyBatched(context.Context, Activity, []Feed, ...BatchOption) (*BatchResult, error)
+	FollowManyBatched(context.Context, []FollowRelationship, ...BatchOption) (*BatchResult, error)
+	UnfollowManyBatched(context.Context, []UnfollowRelationship, ...BatchOption) (*BatchResult, error)
+	SyncFollows(context.Context, Feed, []FeedID, ...SyncFollowsOption) (*SyncFollowsReport, error)
+	SyncFollowsMany(context.Context, map[FeedID][]FeedID, ...SyncFollowsOption) ([]*SyncFollowsReport, error)
+	ExportFollows(context.Context, io.Writer, []FeedID, ...ExportFollowsOption) (int, error)
+	ImportFollows(context.Context, io.Reader, ...ImportFollowsOption) (int, error)
+	ExportActivities(context.Context, io.Writer, []FeedID, ...ExportActivitiesOption) (int, error)
+	ImportActivities(context.Context, io.Reader, ...ImportActivitiesOption) (int, error)
+	EraseUser(context.Context, string, ErasurePlan, ...EraseUserOption) (*ErasureReport, error)
+	ExportUserData(context.Context, string, ErasurePlan, string) (*UserDataManifest, error)
+	ExportUserDataZip(context.Context, string, ErasurePlan, io.Writer) (*UserDataManifest, error)
+	CreateUserToken(string) (string, error)
+	CreateUserTokenWithClaims(string, map[string]any) (string, error)
+}
+
+// Interface returns the ClientInterface backed by the Client.
+func (c *Client) Interface() ClientInterface {
+	return clientInterface{c}
+}
+
+// clientInterface adapts a Client to ClientInterface, returning feeds and
+// sub-clients as interfaces.
+type clientInterface struct {
+	*Client
+}
+
+func (c clientInterface) WithTimeout(timeout time.Duration) ClientInterface {
+	return clientInterface{c.Client.WithTimeout(timeout)}
+}
+
+func (c clientInterface) FlatFeed(slug, userID string) (FlatFeedInterface, error) {
+	feed, err := c.Client.FlatFeed(slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+func (c clientInterface) AggregatedFeed(slug, userID string) (AggregatedFeedInterface, error) {
+	feed, err := c.Client.AggregatedFeed(slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+func (c clientInterface) NotificationFeed(slug, userID string) (NotificationFeedInterface, error) {
+	feed, err := c.Client.NotificationFeed(slug, userID)
+	if err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
+
+func (c clientInterface) FlatFeedFromID(id FeedID) (FlatFeedInterface, error) {
+	return c.FlatFeed(id.Slug, id.UserID)
+}
+
+func (c clientInterface) AggregatedFeedFromID(id FeedID) (AggregatedFeedInterface, error) {
+	return c.AggregatedFeed(id.Slug, id.UserID)
+}
+
+func (c clientInterface) NotificationFeedFromID(id FeedID) (NotificationFeedInterface, error) {
+	return c.NotificationFeed(id.Slug, id.UserID)
+}
+
+func (c clientInterface) Analytics() AnalyticsClientInterface {
+	return c.Client.Analytics()
+}
+
+func (c clientInterface) Collections() CollectionsClientInterface {
+	return c.Client.Collections()
+}
+
+func (c clientInterface) Users() UsersClientInterface {
+	return c.Client.Users()
+}
+
+func (c clientInterface) Reactions() ReactionsClientInterface {
+	return c.Client.Reactions()
+}
+
+func (c clientInterface) Moderation() ModerationClientInterface {
+	return c.Client.Moderation()
+}
+
+func (c clientInterface) AuditLogs() AuditLogsClientInterface {
+	return c.Client.AuditLogs()
+}
+
+func (c clientInterface) Personalization() PersonalizationClientInterface {
+	return c.Client.Personalization()
+}
@@ -0,0 +1,44 @@
+package stream_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestClientInterface(t *testing.T) {
+	client, requester := newClient(t)
+	ci := client.Interface()
+
+	flat, err := ci.FlatFeed("flat", "123")
+	require.NoError(t, err)
+	_, err = flat.GetActivities(context.Background())
+	require.NoError(t, err)
+	testRequest(t, requester.req, "GET", "https://api.stream-io-api.com/api/v1.0/feed/flat/123/?api_key=key", "")
+	assert.Equal(t, stream.FeedID{Slug: "flat", UserID: "123"}, flat.FeedID())
+
+	flat, err = ci.FlatFeed("flat", "#")
+	require.Error(t, err)
+	assert.Nil(t, flat)
+	aggregated, err := ci.AggregatedFeed("aggregated", "#")
+	require.Error(t, err)
+	assert.Nil(t, aggregated)
+	notification, err := ci.NotificationFeed("notification", "#")
+	require.Error(t, err)
+	assert.Nil(t, notification)
+
+	_, err = ci.Users().Get(context.Background(), "u1")
+	require.NoError(t, err)
+	testRequest(t, requester.req, "GET", "https://api.stream-io-api.com/api/v1.0/user/u1/?api_key=key", "")
+
+	assert.Implements(t, (*stream.ClientInterface)(nil), ci.WithTimeout(time.Second))
+	_, ok := ci.FeedGroup("flat")
+	assert.False(t, ok)
+	assert.IsType(t, (*stream.ReactionsClient)(nil), ci.Reactions())
+	assert.IsType(t, (*stream.PersonalizationClient)(nil), ci.Personalization())
+}
@@ -2,7 +2,6 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 )
 
@@ -22,43 +21,47 @@ type flagRequest struct {
 	EntityType string `json:"entity_type"`
 	EntityID   string `json:"entity_id"`
 	Reason     string `json:"reason"`
+	// Custom is additional data of the report, set by FlagOption
+	Custom map[string]any `json:"custom,omitempty"`
 }
 
-func (c *ModerationClient) FlagActivity(ctx context.Context, userID, activityID, reason string) error {
+func (c *ModerationClient) FlagActivity(ctx context.Context, userID, activityID, reason string, opts ...FlagOption) error {
 	r := flagRequest{
 		UserID:     userID,
 		EntityType: ModerationActivity,
 		EntityID:   activityID,
 		Reason:     reason,
 	}
-	return c.flagContent(ctx, r)
+	return c.flagContent(ctx, r, opts)
 }
 
-func (c *ModerationClient) FlagReaction(ctx context.Context, userID, reactionID, reason string) error {
+func (c *ModerationClient) FlagReaction(ctx context.Context, userID, reactionID, reason string, opts ...FlagOption) error {
 	r := flagRequest{
 		UserID:     userID,
 		EntityType: ModerationReaction,
 		EntityID:   reactionID,
 		Reason:     reason,
 	}
-	return c.flagContent(ctx, r)
+	return c.flagContent(ctx, r, opts)
 }
 
-func (c *ModerationClient) FlagUser(ctx context.Context, userID, targetUserID, reason string) error {
+func (c *ModerationClient) FlagUser(ctx context.Context, userID, targetUserID, reason string, opts ...FlagOption) error {
 	r := flagRequest{
 		UserID:     userID,
 		EntityType: ModerationUser,
 		EntityID:   targetUserID,
 		Reason:     reason,
 	}
-	return c.flagContent(ctx, r)
+	return c.flagContent(ctx, r, opts)
 }
 
-func (c *ModerationClient) flagContent(ctx context.Context, r flagRequest) error {
+func (c *ModerationClient) flagContent(ctx context.Context, r flagRequest, opts []FlagOption) error {
+	for _, opt := range opts {
+		opt(&r)
+	}
 	endpoint := c.client.makeEndpoint("moderation/flag/")
 
-	_, err := c.client.post(ctx, endpoint, r, c.client.authenticator.moderationAuth)
-	return err
+	return c.client.post(ctx, endpoint, r, nil, c.client.authenticator.moderationAuth)
 }
 
 type updateStatusRequest struct {
@@ -70,6 +73,10 @@ type updateStatusRequest struct {
 	LatestModeratorAction string `json:"latest_moderator_action"`
 }
 
+// UpdateActivityModerationStatus updates the moderation status of an activity.
+// The status and actions are sent as given.
+//
+// Deprecated: use UpdateActivityStatus, whose arguments can't be swapped.
 func (c *ModerationClient) UpdateActivityModerationStatus(ctx context.Context, activityID, modID, status, recAction, modAction string) error {
 	r := updateStatusRequest{
 		EntityType:            ModerationActivity,
@@ -82,6 +89,10 @@ func (c *ModerationClient) UpdateActivityModerationStatus(ctx context.Context, a
 	return c.updateStatus(ctx, r)
 }
 
+// UpdateReactionModerationStatus updates the moderation status of a reaction.
+// The status and actions are sent as given.
+//
+// Deprecated: use UpdateReactionStatus, whose arguments can't be swapped.
 func (c *ModerationClient) UpdateReactionModerationStatus(ctx context.Context, reactionID, modID, status, recAction, modAction string) error {
 	r := updateStatusRequest{
 		EntityType:            ModerationReaction,
@@ -97,8 +108,7 @@ func (c *ModerationClient) UpdateReactionModerationStatus(ctx context.Context, r
 func (c *ModerationClient) updateStatus(ctx context.Context, r updateStatusRequest) error {
 	endpoint := c.client.makeEndpoint("moderation/status/")
 
-	_, err := c.client.post(ctx, endpoint, r, c.client.authenticator.moderationAuth)
-	return err
+	return c.client.post(ctx, endpoint, r, nil, c.client.authenticator.moderationAuth)
 }
 
 type UpdateStatusBatchRequest struct {
@@ -134,13 +144,8 @@ func (c *ModerationClient) UpdateStatusBatch(ctx context.Context, req UpdateStat
 
 	endpoint := c.client.makeEndpoint("moderation/status/batch/")
 
-	resp, err := c.client.post(ctx, endpoint, req, c.client.authenticator.moderationAuth)
-	if err != nil {
-		return nil, err
-	}
-
 	var result UpdateStatusBatchResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := c.client.post(ctx, endpoint, req, &result, c.client.authenticator.moderationAuth); err != nil {
 		return nil, err
 	}
 
@@ -190,6 +195,5 @@ func (c *ModerationClient) InvalidateUserCache(ctx context.Context, userID strin
 
 	endpoint := c.client.makeEndpoint("moderation/user/cache/%s/", userID)
 
-	_, err := c.client.delete(ctx, endpoint, nil, c.client.authenticator.moderationAuth)
-	return err
+	return c.client.delete(ctx, endpoint, nil, nil, c.client.authenticator.moderationAuth)
 }
@@ -0,0 +1,236 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// Flag is an item of the moderation review queue: an entity flagged by one
+// or more users, along with its moderation status.
+type Flag struct {
+	ID                    string    `json:"id"`
+	EntityType            string    `json:"entity_type"`
+	EntityID              string    `json:"entity_id"`
+	EntityCreatorID       string    `json:"entity_creator_id,omitempty"`
+	Status                string    `json:"status"`
+	RecommendedAction     string    `json:"recommended_action,omitempty"`
+	LatestModeratorAction string    `json:"latest_moderator_action,omitempty"`
+	Reasons               []string  `json:"reasons,omitempty"`
+	FlagsCount            int       `json:"flags_count"`
+	CreatedAt             time.Time `json:"created_at"`
+	UpdatedAt             time.Time `json:"updated_at"`
+}
+
+// FlagReport is a report of a flagged entity by a user.
+type FlagReport struct {
+	UserID    string    `json:"user_id"`
+	Reason    string    `json:"reason"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// FlagHistoryEntry is a past moderation action on a flagged entity.
+type FlagHistoryEntry struct {
+	ModeratorID string    `json:"moderator_id,omitempty"`
+	Action      string    `json:"action"`
+	Status      string    `json:"status"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// FlagResponse is the API response obtained when retrieving a flag.
+type FlagResponse struct {
+	response
+	Flag    Flag               `json:"flag"`
+	Reports []FlagReport       `json:"reports"`
+	History []FlagHistoryEntry `json:"history"`
+}
+
+// QueryFlagsFilters filters the items of the review queue. Empty fields
+// don't filter.
+type QueryFlagsFilters struct {
+	EntityType    string
+	Status        string
+	Reason        string
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
+}
+
+// QueryFlagsPager paginates the items of the review queue.
+type QueryFlagsPager struct {
+	Next  string
+	Limit int
+}
+
+// QueryFlagsResponse is the API response obtained when querying the review
+// queue.
+type QueryFlagsResponse struct {
+	response
+	Flags []Flag `json:"flags"`
+	Next  string `json:"next"`
+}
+
+// ActivityIDs returns the IDs of the flagged activities, to be retrieved
+// with Client.GetActivitiesByID.
+func (r *QueryFlagsResponse) ActivityIDs() []string {
+	return flaggedIDs(r.Flags, ModerationActivity)
+}
+
+// ReactionIDs returns the IDs of the flagged reactions, to be retrieved with
+// Client.GetReactions.
+func (r *QueryFlagsResponse) ReactionIDs() []string {
+	return flaggedIDs(r.Flags, ModerationReaction)
+}
+
+func flaggedIDs(flags []Flag, entityType string) []string {
+	var ids []string
+	for _, f := range flags {
+		if f.EntityType == entityType {
+			ids = append(ids, f.EntityID)
+		}
+	}
+	return ids
+}
+
+// QueryFlags lists the items of the review queue matching the filters, most
+// recently flagged first.
+func (c *ModerationClient) QueryFlags(ctx context.Context, filters QueryFlagsFilters, pager QueryFlagsPager) (*QueryFlagsResponse, error) {
+	endpoint := c.client.makeEndpoint("moderation/flags/")
+	if filters.EntityType != "" {
+		endpoint.addQueryParam(makeRequestOption("entity_type", filters.EntityType))
+	}
+	if filters.Status != "" {
+		endpoint.addQueryParam(makeRequestOption("status", filters.Status))
+	}
+	if filters.Reason != "" {
+		endpoint.addQueryParam(makeRequestOption("reason", filters.Reason))
+	}
+	if !filters.CreatedAfter.IsZero() {
+		endpoint.addQueryParam(makeRequestOption("created_at_gte", filters.CreatedAfter.UTC().Format(time.RFC3339)))
+	}
+	if !filters.CreatedBefore.IsZero() {
+		endpoint.addQueryParam(makeRequestOption("created_at_lte", filters.CreatedBefore.UTC().Format(time.RFC3339)))
+	}
+	if pager.Next != "" {
+		endpoint.addQueryParam(makeRequestOption("next", pager.Next))
+	}
+	if pager.Limit > 0 {
+		endpoint.addQueryParam(makeRequestOption("limit", pager.Limit))
+	}
+	var resp QueryFlagsResponse
+	if err := c.client.get(ctx, endpoint, nil, &resp, c.client.authenticator.moderationAuth); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// GetFlag retrieves an item of the review queue with its reports and the
+// history of its moderation.
+func (c *ModerationClient) GetFlag(ctx context.Context, flagID string) (*FlagResponse, error) {
+	if flagID == "" {
+		return nil, errors.New("empty flagID")
+	}
+	endpoint := c.client.makeEndpoint("moderation/flags/%s/", flagID)
+	var resp FlagResponse
+	if err := c.client.get(ctx, endpoint, nil, &resp, c.client.authenticator.moderationAuth); err != nil {
+		return nil, err
+	}
+	return &resp, nil
+}
+
+// ResolvedFlags holds the flagged activities and reactions of review queue
+// items, by ID. Removed entities are missing.
+type ResolvedFlags struct {
+	Activities map[string]Activity
+	Reactions  map[string]Reaction
+}
+
+// ResolveFlags retrieves the flagged activities and reactions of the given
+// review queue items.
+func (c *ModerationClient) ResolveFlags(ctx context.Context, flags []Flag) (*ResolvedFlags, error) {
+	resolved := &ResolvedFlags{
+		Activities: make(map[string]Activity),
+		Reactions:  make(map[string]Reaction),
+	}
+	if ids := flaggedIDs(flags, ModerationActivity); len(ids) > 0 {
+		resp, err := c.client.GetActivitiesByID(ctx, ids...)
+		if err != nil {
+			return nil, err
+		}
+		for _, a := range resp.Results {
+			resolved.Activities[a.ID] = a
+		}
+	}
+	if ids := flaggedIDs(flags, ModerationReaction); len(ids) > 0 {
+		resp, err := c.client.GetReactions(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range resp.Results {
+			resolved.Reactions[r.ID] = r
+		}
+	}
+	return resolved, nil
+}
+
+// UnflagActivity withdraws the flag of an activity by a user.
+func (c *ModerationClient) UnflagActivity(ctx context.Context, userID, activityID string) error {
+	return c.unflagContent(ctx, userID, ModerationActivity, activityID)
+}
+
+// UnflagReaction withdraws the flag of a reaction by a user.
+func (c *ModerationClient) UnflagReaction(ctx context.Context, userID, reactionID string) error {
+	return c.unflagContent(ctx, userID, ModerationReaction, reactionID)
+}
+
+// UnflagUser withdraws the flag of a user by another user.
+func (c *ModerationClient) UnflagUser(ctx context.Context, userID, targetUserID string) error {
+	return c.unflagContent(ctx, userID, ModerationUser, targetUserID)
+}
+
+func (c *ModerationClient) unflagContent(ctx context.Context, userID, entityType, entityID string) error {
+	r := struct {
+		UserID     string `json:"user_id"`
+		EntityType string `json:"entity_type"`
+		EntityID   string `json:"entity_id"`
+	}{userID, entityType, entityID}
+	endpoint := c.client.makeEndpoint("moderation/unflag/")
+
+	return c.client.post(ctx, endpoint, r, nil, c.client.authenticator.moderationAuth)
+}
+
+type banRequest struct {
+	ModeratorID  string `json:"moderator_id"`
+	TargetUserID string `json:"target_user_id"`
+	Reason       string `json:"reason,omitempty"`
+	// Timeout is the duration of the ban in minutes, zero being permanent.
+	Timeout int `json:"timeout,omitempty"`
+}
+
+// BanUser bans a user for the given duration, rounded up to minutes, or
+// permanently if zero.
+func (c *ModerationClient) BanUser(ctx context.Context, moderatorID, targetUserID, reason string, duration time.Duration) error {
+	if targetUserID == "" {
+		return errors.New("empty targetUserID")
+	}
+	r := banRequest{
+		ModeratorID:  moderatorID,
+		TargetUserID: targetUserID,
+		Reason:       reason,
+		Timeout:      int((duration + time.Minute - 1) / time.Minute),
+	}
+	endpoint := c.client.makeEndpoint("moderation/ban/")
+
+	return c.client.post(ctx, endpoint, r, nil, c.client.authenticator.moderationAuth)
+}
+
+// UnbanUser lifts the ban of a user.
+func (c *ModerationClient) UnbanUser(ctx context.Context, moderatorID, targetUserID string) error {
+	if targetUserID == "" {
+		return errors.New("empty targetUserID")
+	}
+	endpoint := c.client.makeEndpoint("moderation/ban/")
+	endpoint.addQueryParam(makeRequestOption("target_user_id", targetUserID))
+	endpoint.addQueryParam(makeRequestOption("moderator_id", moderatorID))
+
+	return c.client.delete(ctx, endpoint, nil, nil, c.client.authenticator.moderationAuth)
+}
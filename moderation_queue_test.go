@@ -0,0 +1,134 @@
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestQueryFlags(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	requester.resp = `{
+		"flags": [
+			{"id": "f1", "entity_type": "stream:feeds:v2:activity", "entity_id": "a1", "status": "pending", "flags_count": 2, "reasons": ["spam"]},
+			{"id": "f2", "entity_type": "stream:feeds:v2:reaction", "entity_id": "r1", "status": "pending", "flags_count": 1},
+			{"id": "f3", "entity_type": "stream:user", "entity_id": "u1", "status": "pending", "flags_count": 1}
+		],
+		"next": "cursor2"
+	}`
+
+	resp, err := client.Moderation().QueryFlags(ctx, stream.QueryFlagsFilters{
+		EntityType:    stream.ModerationActivity,
+		Status:        "pending",
+		Reason:        "spam",
+		CreatedAfter:  time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC),
+		CreatedBefore: time.Date(2024, 2, 1, 0, 0, 0, 0, time.UTC),
+	}, stream.QueryFlagsPager{Next: "cursor1", Limit: 3})
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet,
+		"https://api.stream-io-api.com/api/v1.0/moderation/flags/?api_key=key&created_at_gte=2024-01-01T00%3A00%3A00Z&created_at_lte=2024-02-01T00%3A00%3A00Z&entity_type=stream%3Afeeds%3Av2%3Aactivity&limit=3&next=cursor1&reason=spam&status=pending", "")
+	require.Len(t, resp.Flags, 3)
+	assert.Equal(t, 2, resp.Flags[0].FlagsCount)
+	assert.Equal(t, []string{"spam"}, resp.Flags[0].Reasons)
+	assert.Equal(t, "cursor2", resp.Next)
+	assert.Equal(t, []string{"a1"}, resp.ActivityIDs())
+	assert.Equal(t, []string{"r1"}, resp.ReactionIDs())
+
+	_, err = client.Moderation().QueryFlags(ctx, stream.QueryFlagsFilters{}, stream.QueryFlagsPager{})
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://api.stream-io-api.com/api/v1.0/moderation/flags/?api_key=key", "")
+}
+
+func TestGetFlag(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	requester.resp = `{
+		"flag": {"id": "f1", "entity_type": "stream:feeds:v2:activity", "entity_id": "a1", "status": "complete"},
+		"reports": [{"user_id": "jimmy", "reason": "spam"}],
+		"history": [{"moderator_id": "mod", "action": "mark_safe", "status": "complete"}]
+	}`
+
+	resp, err := client.Moderation().GetFlag(ctx, "f1")
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://api.stream-io-api.com/api/v1.0/moderation/flags/f1/?api_key=key", "")
+	assert.Equal(t, "a1", resp.Flag.EntityID)
+	assert.Equal(t, "jimmy", resp.Reports[0].UserID)
+	assert.Equal(t, "mark_safe", resp.History[0].Action)
+
+	_, err = client.Moderation().GetFlag(ctx, "")
+	assert.Error(t, err)
+}
+
+func TestResolveFlags(t *testing.T) {
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		if strings.Contains(r.path, "reaction") {
+			return jsonResponse(http.StatusOK, `{"reactions":[{"id":"r1","kind":"comment"}]}`), nil
+		}
+		return jsonResponse(http.StatusOK, `{"results":[{"id":"a1","verb":"post"}]}`), nil
+	})
+
+	resolved, err := client.Moderation().ResolveFlags(context.Background(), []stream.Flag{
+		{EntityType: stream.ModerationActivity, EntityID: "a1"},
+		{EntityType: stream.ModerationActivity, EntityID: "a2"},
+		{EntityType: stream.ModerationReaction, EntityID: "r1"},
+		{EntityType: stream.ModerationUser, EntityID: "u1"},
+	})
+	require.NoError(t, err)
+	requests := requester.recorded()
+	require.Len(t, requests, 2)
+	assert.Equal(t, "GET /api/v1.0/activities/", requests[0].String())
+	assert.Equal(t, "a1,a2", requests[0].query.Get("ids"))
+	assert.Equal(t, "GET /api/v1.0/reaction/get_many/", requests[1].String())
+	assert.Equal(t, "r1", requests[1].query.Get("ids"))
+	assert.Equal(t, "post", resolved.Activities["a1"].Verb)
+	assert.Equal(t, "comment", resolved.Reactions["r1"].Kind)
+
+	requester.reset()
+	_, err = client.Moderation().ResolveFlags(context.Background(), []stream.Flag{{EntityType: stream.ModerationUser, EntityID: "u1"}})
+	require.NoError(t, err)
+	assert.Empty(t, requester.recorded())
+}
+
+func TestUnflag(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+
+	require.NoError(t, client.Moderation().UnflagActivity(ctx, "jimmy", "foo"))
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/moderation/unflag/?api_key=key",
+		`{"entity_id":"foo", "entity_type":"stream:feeds:v2:activity", "user_id":"jimmy"}`)
+
+	require.NoError(t, client.Moderation().UnflagReaction(ctx, "jimmy", "foo"))
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/moderation/unflag/?api_key=key",
+		`{"entity_id":"foo", "entity_type":"stream:feeds:v2:reaction", "user_id":"jimmy"}`)
+
+	require.NoError(t, client.Moderation().UnflagUser(ctx, "jimmy", "foo"))
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/moderation/unflag/?api_key=key",
+		`{"entity_id":"foo", "entity_type":"stream:user", "user_id":"jimmy"}`)
+}
+
+func TestBanUser(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+
+	require.NoError(t, client.Moderation().BanUser(ctx, "mod", "bob", "spam", 90*time.Second))
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/moderation/ban/?api_key=key",
+		`{"moderator_id":"mod", "target_user_id":"bob", "reason":"spam", "timeout":2}`)
+
+	require.NoError(t, client.Moderation().BanUser(ctx, "mod", "bob", "", 0))
+	testRequest(t, requester.req, http.MethodPost, "https://api.stream-io-api.com/api/v1.0/moderation/ban/?api_key=key",
+		`{"moderator_id":"mod", "target_user_id":"bob"}`)
+
+	require.NoError(t, client.Moderation().UnbanUser(ctx, "mod", "bob"))
+	testRequest(t, requester.req, http.MethodDelete, "https://api.stream-io-api.com/api/v1.0/moderation/ban/?api_key=key&moderator_id=mod&target_user_id=bob", "")
+
+	assert.Error(t, client.Moderation().BanUser(ctx, "mod", "", "", 0))
+	assert.Error(t, client.Moderation().UnbanUser(ctx, "mod", ""))
+}
@@ -0,0 +1,108 @@
+package stream
+
+import (
+	"context"
+	"fmt"
+)
+
+// ModerationStatus is the review status of a moderated entity. The constants
+// are the common statuses, and other values are sent as given.
+type ModerationStatus string
+
+const (
+	ModerationStatusPending  ModerationStatus = "pending"
+	ModerationStatusComplete ModerationStatus = "complete"
+)
+
+// ModerationAction is an action recommended by the automated moderation or
+// taken by a moderator on a moderated entity. The constants are the common
+// actions, and other values are sent as given.
+type ModerationAction string
+
+const (
+	ModerationActionKeep        ModerationAction = "keep"
+	ModerationActionWatch       ModerationAction = "watch"
+	ModerationActionFlag        ModerationAction = "flag"
+	ModerationActionRemove      ModerationAction = "remove"
+	ModerationActionMarkSafe    ModerationAction = "mark_safe"
+	ModerationActionMarkHarmful ModerationAction = "mark_harmful"
+)
+
+// ModerationStatusUpdate is a change of the moderation status of an entity,
+// made by a moderator. The status is required, while the actions are
+// optional.
+type ModerationStatusUpdate struct {
+	ModeratorID           string
+	Status                ModerationStatus
+	RecommendedAction     ModerationAction
+	LatestModeratorAction ModerationAction
+}
+
+// UpdateActivityStatus updates the moderation status of an activity.
+func (c *ModerationClient) UpdateActivityStatus(ctx context.Context, activityID string, u ModerationStatusUpdate) error {
+	return c.updateStatusTyped(ctx, ModerationActivity, activityID, u)
+}
+
+// UpdateReactionStatus updates the moderation status of a reaction.
+func (c *ModerationClient) UpdateReactionStatus(ctx context.Context, reactionID string, u ModerationStatusUpdate) error {
+	return c.updateStatusTyped(ctx, ModerationReaction, reactionID, u)
+}
+
+func (c *ModerationClient) updateStatusTyped(ctx context.Context, entityType, entityID string, u ModerationStatusUpdate) error {
+	r := updateStatusRequest{
+		EntityType:            entityType,
+		EntityID:              entityID,
+		ModeratorID:           u.ModeratorID,
+		Status:                string(u.Status),
+		RecommendedAction:     string(u.RecommendedAction),
+		LatestModeratorAction: string(u.LatestModeratorAction),
+	}
+	if err := validateUpdateStatusRequest(r); err != nil {
+		return err
+	}
+	return c.updateStatus(ctx, r)
+}
+
+func validateUpdateStatusRequest(r updateStatusRequest) error {
+	if r.ModeratorID == "" {
+		return fmt.Errorf("moderator_id is required")
+	}
+	if r.EntityID == "" {
+		return fmt.Errorf("entity_id is required")
+	}
+	if r.Status == "" {
+		return fmt.Errorf("status is required")
+	}
+	return nil
+}
+
+// FlagOption adds data to a flag, beyond its reason.
+type FlagOption func(*flagRequest)
+
+// WithFlagCategory sets the category of the report, such as "spam".
+func WithFlagCategory(category string) FlagOption {
+	return withFlagCustom("category", category)
+}
+
+// WithFlagText sets the free text of the report.
+func WithFlagText(text string) FlagOption {
+	return withFlagCustom("text", text)
+}
+
+// WithFlagCustom adds the given custom data to the report.
+func WithFlagCustom(custom map[string]any) FlagOption {
+	return func(r *flagRequest) {
+		for k, v := range custom {
+			withFlagCustom(k, v)(r)
+		}
+	}
+}
+
+func withFlagCustom(key string, value any) FlagOption {
+	return func(r *flagRequest) {
+		if r.Custom == nil {
+			r.Custom = make(map[string]any)
+		}
+		r.Custom[key] = value
+	}
+}
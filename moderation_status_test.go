@@ -0,0 +1,137 @@
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestUpdateActivityStatus(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	err := client.Moderation().UpdateActivityStatus(ctx, "foo", stream.ModerationStatusUpdate{
+		ModeratorID:           "moderator_123",
+		Status:                stream.ModerationStatusComplete,
+		RecommendedAction:     stream.ModerationActionWatch,
+		LatestModeratorAction: stream.ModerationActionMarkSafe,
+	})
+	require.NoError(t, err)
+	testRequest(
+		t,
+		requester.req,
+		http.MethodPost,
+		"https://api.stream-io-api.com/api/v1.0/moderation/status/?api_key=key",
+		`{"entity_id":"foo", "entity_type":"stream:feeds:v2:activity", "moderator_id": "moderator_123", "latest_moderator_action":"mark_safe", "recommended_action":"watch", "status":"complete"}`,
+	)
+
+	err = client.Moderation().UpdateReactionStatus(ctx, "bar", stream.ModerationStatusUpdate{
+		ModeratorID: "moderator_123",
+		Status:      stream.ModerationStatusPending,
+	})
+	require.NoError(t, err)
+	testRequest(
+		t,
+		requester.req,
+		http.MethodPost,
+		"https://api.stream-io-api.com/api/v1.0/moderation/status/?api_key=key",
+		`{"entity_id":"bar", "entity_type":"stream:feeds:v2:reaction", "moderator_id": "moderator_123", "latest_moderator_action":"", "recommended_action":"", "status":"pending"}`,
+	)
+}
+
+func TestUpdateStatusValidation(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	moderation := client.Moderation()
+
+	testCases := []struct {
+		name string
+		call func() error
+		err  string
+	}{
+		{
+			name: "missing moderator",
+			call: func() error {
+				return moderation.UpdateActivityStatus(ctx, "foo", stream.ModerationStatusUpdate{Status: stream.ModerationStatusComplete})
+			},
+			err: "moderator_id is required",
+		},
+		{
+			name: "missing entity",
+			call: func() error {
+				return moderation.UpdateReactionStatus(ctx, "", stream.ModerationStatusUpdate{ModeratorID: "mod", Status: stream.ModerationStatusComplete})
+			},
+			err: "entity_id is required",
+		},
+		{
+			name: "missing status",
+			call: func() error {
+				return moderation.UpdateActivityStatus(ctx, "foo", stream.ModerationStatusUpdate{ModeratorID: "mod"})
+			},
+			err: "status is required",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			requester.req = nil
+			assert.EqualError(t, tc.call(), tc.err)
+			assert.Nil(t, requester.req)
+		})
+	}
+}
+
+func TestUpdateStatusUntypedValues(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	moderation := client.Moderation()
+
+	err := moderation.UpdateActivityModerationStatus(ctx, "foo", "moderator_123", "escalated", "review", "")
+	require.NoError(t, err)
+	testRequest(
+		t,
+		requester.req,
+		http.MethodPost,
+		"https://api.stream-io-api.com/api/v1.0/moderation/status/?api_key=key",
+		`{"entity_id":"foo", "entity_type":"stream:feeds:v2:activity", "moderator_id": "moderator_123", "latest_moderator_action":"", "recommended_action":"review", "status":"escalated"}`,
+	)
+
+	err = moderation.UpdateReactionStatus(ctx, "bar", stream.ModerationStatusUpdate{ModeratorID: "moderator_123", Status: "escalated", RecommendedAction: "review"})
+	require.NoError(t, err)
+	testRequest(
+		t,
+		requester.req,
+		http.MethodPost,
+		"https://api.stream-io-api.com/api/v1.0/moderation/status/?api_key=key",
+		`{"entity_id":"bar", "entity_type":"stream:feeds:v2:reaction", "moderator_id": "moderator_123", "latest_moderator_action":"", "recommended_action":"review", "status":"escalated"}`,
+	)
+
+	_, err = moderation.UpdateStatusBatch(ctx, stream.UpdateStatusBatchRequest{
+		ModeratorID: "moderator_123",
+		Updates: []stream.UpdateStatusBatchItem{
+			{EntityType: stream.ModerationActivity, EntityID: "a1", Status: "escalated", RecommendedAction: "review"},
+		},
+	})
+	require.NoError(t, err)
+}
+
+func TestFlagWithCustomData(t *testing.T) {
+	ctx := context.Background()
+	client, requester := newClient(t)
+	err := client.Moderation().FlagActivity(ctx, "jimmy", "foo", "reason1",
+		stream.WithFlagCategory("spam"),
+		stream.WithFlagText("selling stuff"),
+		stream.WithFlagCustom(map[string]any{"source": "app"}),
+	)
+	require.NoError(t, err)
+	testRequest(
+		t,
+		requester.req,
+		http.MethodPost,
+		"https://api.stream-io-api.com/api/v1.0/moderation/flag/?api_key=key",
+		`{"entity_id":"foo", "entity_type":"stream:feeds:v2:activity", "reason":"reason1", "user_id":"jimmy", "custom":{"category":"spam", "text":"selling stuff", "source":"app"}}`,
+	)
+}
@@ -2,7 +2,6 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 )
 
 // NotificationFeed is a Stream notification feed.
@@ -13,12 +12,8 @@ type NotificationFeed struct {
 // GetActivities returns the activities for the given NotificationFeed, filtering
 // results with the provided GetActivitiesOption parameters.
 func (f *NotificationFeed) GetActivities(ctx context.Context, opts ...GetActivitiesOption) (*NotificationFeedResponse, error) {
-	body, err := f.client.getActivities(ctx, f, opts...)
-	if err != nil {
-		return nil, err
-	}
 	var resp NotificationFeedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := f.client.getActivities(ctx, f, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -37,12 +32,8 @@ func (f *NotificationFeed) GetNextPageActivities(ctx context.Context, resp *Noti
 // GetEnrichedActivities returns the enriched activities for the given NotificationFeed, filtering
 // results with the provided GetActivitiesOption parameters.
 func (f *NotificationFeed) GetEnrichedActivities(ctx context.Context, opts ...GetActivitiesOption) (*EnrichedNotificationFeedResponse, error) {
-	body, err := f.client.getEnrichedActivities(ctx, f, opts...)
-	if err != nil {
-		return nil, err
-	}
 	var resp EnrichedNotificationFeedResponse
-	if err := json.Unmarshal(body, &resp); err != nil {
+	if err := f.client.getEnrichedActivities(ctx, f, &resp, opts...); err != nil {
 		return nil, err
 	}
 	return &resp, nil
@@ -298,6 +298,22 @@ func WithToTargetsRemove(targets ...string) UpdateToTargetsOption {
 	}
 }
 
+// WithToTargetsNewIDs is like WithToTargetsNew, taking the targets as FeedIDs.
+func WithToTargetsNewIDs(ids ...FeedID) UpdateToTargetsOption {
+	return WithToTargetsNew(FeedIDStrings(ids...)...)
+}
+
+// WithToTargetsAddIDs is like WithToTargetsAdd, taking the targets as FeedIDs.
+func WithToTargetsAddIDs(ids ...FeedID) UpdateToTargetsOption {
+	return WithToTargetsAdd(FeedIDStrings(ids...)...)
+}
+
+// WithToTargetsRemoveIDs is like WithToTargetsRemove, taking the targets as
+// FeedIDs.
+func WithToTargetsRemoveIDs(ids ...FeedID) UpdateToTargetsOption {
+	return WithToTargetsRemove(FeedIDStrings(ids...)...)
+}
+
 // AddObjectOption is an option usable by the Collections.Add method.
 type AddObjectOption func(*addCollectionRequest)
 
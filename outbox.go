@@ -0,0 +1,456 @@
+package stream
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+// Outbox operations.
+const (
+	OutboxAddActivity            = "add_activity"
+	OutboxAddToMany              = "add_to_many"
+	OutboxFollowMany             = "follow_many"
+	OutboxAddReaction            = "add_reaction"
+	OutboxUpsertCollection       = "upsert_collection"
+	OutboxUpdateCollectionObject = "update_collection_object"
+	OutboxDeleteCollectionObject = "delete_collection_object"
+)
+
+var errOutboxClosed = errors.New("outbox is closed")
+
+// OutboxEntry is a write stored in an Outbox, waiting to be sent to Stream.
+type OutboxEntry struct {
+	Seq       uint64          `json:"seq"`
+	Op        string          `json:"op"`
+	Payload   json.RawMessage `json:"payload"`
+	CreatedAt time.Time       `json:"created_at"`
+}
+
+// OutboxStore persists the entries of an Outbox until they're acknowledged.
+type OutboxStore interface {
+	// Append durably stores the entry.
+	Append(OutboxEntry) error
+	// Ack removes the entry having the given sequence number.
+	Ack(seq uint64) error
+	// Pending returns the stored entries which were not acknowledged, in
+	// order.
+	Pending() ([]OutboxEntry, error)
+}
+
+// MemoryOutboxStore is a non durable OutboxStore keeping the entries in memory.
+type MemoryOutboxStore struct {
+	mu      sync.Mutex
+	entries []OutboxEntry
+}
+
+// Append stores the entry.
+func (s *MemoryOutboxStore) Append(e OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.entries = append(s.entries, e)
+	return nil
+}
+
+// Ack removes the entry having the given sequence number.
+func (s *MemoryOutboxStore) Ack(seq uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for i, e := range s.entries {
+		if e.Seq == seq {
+			s.entries = append(s.entries[:i], s.entries[i+1:]...)
+			break
+		}
+	}
+	return nil
+}
+
+// Pending returns the stored entries.
+func (s *MemoryOutboxStore) Pending() ([]OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]OutboxEntry(nil), s.entries...), nil
+}
+
+// Outbox durably queues writes and replays them to Stream in order in the
+// background, so that they survive Stream being unreachable and process
+// restarts. Writes failing with connection errors, rate limiting or server
+// errors are retried until they succeed, while writes rejected by Stream are
+// dropped and reported to the error handler.
+//
+// Writes are delivered at least once: activities get a foreign ID and a time
+// and reactions get an ID when queued if missing, so that replays don't create
+// duplicates.
+type Outbox struct {
+	client  *Client
+	store   OutboxStore
+	policy  RetryPolicy
+	onError func(OutboxEntry, error)
+
+	mu      sync.Mutex
+	seq     uint64
+	queue   []OutboxEntry
+	waiters []chan struct{}
+	closed  bool
+
+	wake   chan struct{}
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+// OutboxOption configures an Outbox.
+type OutboxOption func(*Outbox)
+
+// WithOutboxRetryPolicy sets the backoff between the replays of failing
+// writes. MaxRetries is ignored as writes are retried until they succeed.
+func WithOutboxRetryPolicy(policy RetryPolicy) OutboxOption {
+	return func(o *Outbox) {
+		o.policy = policy
+	}
+}
+
+// WithOutboxErrorHandler sets the function called with the writes rejected
+// by Stream, which are then dropped.
+func WithOutboxErrorHandler(fn func(OutboxEntry, error)) OutboxOption {
+	return func(o *Outbox) {
+		o.onError = fn
+	}
+}
+
+// NewOutbox returns an Outbox sending the writes stored in store with client,
+// starting with the pending ones. Close must be called to stop it.
+func NewOutbox(client *Client, store OutboxStore, opts ...OutboxOption) (*Outbox, error) {
+	if client == nil || store == nil {
+		return nil, errors.New("missing client or store")
+	}
+	pending, err := store.Pending()
+	if err != nil {
+		return nil, fmt.Errorf("cannot load pending outbox entries: %w", err)
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	o := &Outbox{
+		client: client,
+		store:  store,
+		policy: RetryPolicy{Backoff: time.Second, MaxBackoff: time.Minute},
+		queue:  pending,
+		wake:   make(chan struct{}, 1),
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	for _, e := range pending {
+		if e.Seq > o.seq {
+			o.seq = e.Seq
+		}
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	go o.run(ctx)
+	return o, nil
+}
+
+type outboxActivity struct {
+	Feed     *FeedID  `json:"feed,omitempty"`
+	Feeds    []FeedID `json:"feeds,omitempty"`
+	Activity Activity `json:"activity"`
+}
+
+type outboxCollection struct {
+	Collection string             `json:"collection"`
+	ID         string             `json:"id,omitempty"`
+	Data       map[string]any     `json:"data,omitempty"`
+	Objects    []outboxCollObject `json:"objects,omitempty"`
+}
+
+type outboxCollObject struct {
+	ID   string         `json:"id,omitempty"`
+	Data map[string]any `json:"data"`
+}
+
+// AddActivity queues the addition of the activity to the feed.
+func (o *Outbox) AddActivity(feed FeedID, activity Activity) error {
+	return o.enqueue(OutboxAddActivity, outboxActivity{Feed: &feed, Activity: o.prepareActivity(activity)})
+}
+
+// AddToMany queues the addition of the activity to the feeds.
+func (o *Outbox) AddToMany(activity Activity, feeds ...FeedID) error {
+	return o.enqueue(OutboxAddToMany, outboxActivity{Feeds: feeds, Activity: o.prepareActivity(activity)})
+}
+
+// FollowMany queues the creation of the follow relationships.
+func (o *Outbox) FollowMany(relationships []FollowRelationship) error {
+	return o.enqueue(OutboxFollowMany, relationships)
+}
+
+// AddReaction queues the addition of the reaction, or of the child reaction
+// if its ParentID is set.
+func (o *Outbox) AddReaction(r AddReactionRequestObject) error {
+	if r.ID == "" {
+		r.ID = newUUID()
+	}
+	return o.enqueue(OutboxAddReaction, r)
+}
+
+// UpsertCollection queues the upsert of the objects in the collection.
+func (o *Outbox) UpsertCollection(collection string, objects ...CollectionObject) error {
+	p := outboxCollection{Collection: collection, Objects: make([]outboxCollObject, len(objects))}
+	for i, obj := range objects {
+		p.Objects[i] = outboxCollObject(obj)
+	}
+	return o.enqueue(OutboxUpsertCollection, p)
+}
+
+// UpdateCollectionObject queues the update of the collection object.
+func (o *Outbox) UpdateCollectionObject(collection, id string, data map[string]any) error {
+	return o.enqueue(OutboxUpdateCollectionObject, outboxCollection{Collection: collection, ID: id, Data: data})
+}
+
+// DeleteCollectionObject queues the deletion of the collection object.
+func (o *Outbox) DeleteCollectionObject(collection, id string) error {
+	return o.enqueue(OutboxDeleteCollectionObject, outboxCollection{Collection: collection, ID: id})
+}
+
+// Backlog returns the number of writes waiting to be sent.
+func (o *Outbox) Backlog() int {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return len(o.queue)
+}
+
+// Flush sends the pending writes right away, skipping any retry backoff, and
+// waits until they're all sent or ctx is done.
+func (o *Outbox) Flush(ctx context.Context) error {
+	o.mu.Lock()
+	if len(o.queue) == 0 {
+		o.mu.Unlock()
+		return nil
+	}
+	if o.closed {
+		o.mu.Unlock()
+		return errOutboxClosed
+	}
+	ch := make(chan struct{})
+	o.waiters = append(o.waiters, ch)
+	o.mu.Unlock()
+	o.notify()
+
+	select {
+	case <-ch:
+		return nil
+	case <-o.done:
+		return errOutboxClosed
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close stops sending writes, leaving the pending ones in the store, and
+// closes the store if it implements io.Closer.
+func (o *Outbox) Close() error {
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return nil
+	}
+	o.closed = true
+	o.mu.Unlock()
+	o.cancel()
+	<-o.done
+	if c, ok := o.store.(io.Closer); ok {
+		return c.Close()
+	}
+	return nil
+}
+
+func (o *Outbox) prepareActivity(a Activity) Activity {
+	return identifyActivity(a, ContentForeignID, time.Now())
+}
+
+func (o *Outbox) enqueue(op string, payload any) error {
+	b, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("cannot encode outbox entry: %w", err)
+	}
+	o.mu.Lock()
+	if o.closed {
+		o.mu.Unlock()
+		return errOutboxClosed
+	}
+	e := OutboxEntry{Seq: o.seq + 1, Op: op, Payload: b, CreatedAt: time.Now().UTC()}
+	if err := o.store.Append(e); err != nil {
+		o.mu.Unlock()
+		return fmt.Errorf("cannot store outbox entry: %w", err)
+	}
+	o.seq = e.Seq
+	o.queue = append(o.queue, e)
+	o.mu.Unlock()
+	o.notify()
+	return nil
+}
+
+func (o *Outbox) notify() {
+	select {
+	case o.wake <- struct{}{}:
+	default:
+	}
+}
+
+func (o *Outbox) head() (OutboxEntry, bool) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.queue) == 0 {
+		for _, ch := range o.waiters {
+			close(ch)
+		}
+		o.waiters = nil
+		return OutboxEntry{}, false
+	}
+	return o.queue[0], true
+}
+
+func (o *Outbox) ack(e OutboxEntry) error {
+	if err := o.store.Ack(e.Seq); err != nil {
+		return err
+	}
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if len(o.queue) > 0 && o.queue[0].Seq == e.Seq {
+		o.queue = o.queue[1:]
+	}
+	return nil
+}
+
+func (o *Outbox) run(ctx context.Context) {
+	defer close(o.done)
+	attempt := 0
+	for {
+		e, ok := o.head()
+		if !ok {
+			select {
+			case <-o.wake:
+				continue
+			case <-ctx.Done():
+				return
+			}
+		}
+
+		err := o.send(ctx, e)
+		if (err != nil && isRetryable(ctx, err)) || ctx.Err() != nil {
+			if !o.backoff(ctx, attempt) {
+				return
+			}
+			attempt++
+			continue
+		}
+		attempt = 0
+		if err != nil && o.onError != nil {
+			o.onError(e, err)
+		}
+		if err := o.ack(e); err != nil {
+			// keep the entry, it'll be sent again
+			if !o.backoff(ctx, 0) {
+				return
+			}
+		}
+	}
+}
+
+// backoff waits before the next attempt, returning early when flushed and
+// false when stopped.
+func (o *Outbox) backoff(ctx context.Context, attempt int) bool {
+	t := time.NewTimer(o.policy.delay(attempt))
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return true
+	case <-o.wake:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+func (o *Outbox) send(ctx context.Context, e OutboxEntry) error {
+	c := o.client
+	switch e.Op {
+	case OutboxAddActivity:
+		var p outboxActivity
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		if p.Feed == nil {
+			return errors.New("missing feed")
+		}
+		feed, err := c.GenericFeed(p.Feed.String())
+		if err != nil {
+			return err
+		}
+		_, err = feed.AddActivity(ctx, p.Activity)
+		return err
+	case OutboxAddToMany:
+		var p outboxActivity
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		feeds := make([]Feed, len(p.Feeds))
+		for i, id := range p.Feeds {
+			feed, err := c.GenericFeed(id.String())
+			if err != nil {
+				return err
+			}
+			feeds[i] = feed
+		}
+		return c.AddToMany(ctx, p.Activity, feeds...)
+	case OutboxFollowMany:
+		var p []FollowRelationship
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		return c.FollowMany(ctx, p)
+	case OutboxAddReaction:
+		var p AddReactionRequestObject
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		// the reaction has an ID, so a conflict means a previous replay added it
+		if _, err := c.Reactions().addReaction(ctx, p); err != nil && !isConflict(err) {
+			return err
+		}
+		return nil
+	case OutboxUpsertCollection, OutboxUpdateCollectionObject, OutboxDeleteCollectionObject:
+		var p outboxCollection
+		if err := json.Unmarshal(e.Payload, &p); err != nil {
+			return err
+		}
+		var err error
+		switch e.Op {
+		case OutboxUpsertCollection:
+			objects := make([]CollectionObject, len(p.Objects))
+			for i, obj := range p.Objects {
+				objects[i] = CollectionObject(obj)
+			}
+			_, err = c.Collections().Upsert(ctx, p.Collection, objects...)
+		case OutboxUpdateCollectionObject:
+			_, err = c.Collections().Update(ctx, p.Collection, p.ID, p.Data)
+		default:
+			_, err = c.Collections().Delete(ctx, p.Collection, p.ID)
+		}
+		return err
+	default:
+		return fmt.Errorf("unknown outbox operation %q", e.Op)
+	}
+}
+
+// newUUID returns a random version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
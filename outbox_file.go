@@ -0,0 +1,205 @@
+package stream
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// FileOutboxStore is an OutboxStore backed by an append-only file of JSON
+// lines, where entries and acknowledgements are appended and synced to disk.
+// The file is compacted when opened and truncated when no entries are
+// pending. A line left incomplete by a crash is discarded when opened.
+type FileOutboxStore struct {
+	mu      sync.Mutex
+	path    string
+	f       *os.File
+	pending map[uint64]OutboxEntry
+}
+
+// outboxRecord is a line of the file, either an entry or an acknowledgement.
+type outboxRecord struct {
+	OutboxEntry
+	outboxAck
+}
+
+type outboxAck struct {
+	Ack uint64 `json:"ack,omitempty"`
+}
+
+// OpenFileOutboxStore opens the FileOutboxStore at path, creating the file
+// if needed.
+func OpenFileOutboxStore(path string) (*FileOutboxStore, error) {
+	s := &FileOutboxStore{
+		path:    path,
+		pending: make(map[uint64]OutboxEntry),
+	}
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+	if err := s.compact(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND|os.O_CREATE, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	s.f = f
+	return s, nil
+}
+
+func (s *FileOutboxStore) load() error {
+	f, err := os.Open(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	for line := 1; ; line++ {
+		b, err := r.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// incomplete last line, written partially before a crash
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		b = bytes.TrimSpace(b)
+		if len(b) == 0 {
+			continue
+		}
+		var rec outboxRecord
+		if err := json.Unmarshal(b, &rec); err != nil {
+			return fmt.Errorf("corrupted outbox file %s at line %d: %w", s.path, line, err)
+		}
+		if rec.Ack != 0 {
+			delete(s.pending, rec.Ack)
+		} else {
+			s.pending[rec.Seq] = rec.OutboxEntry
+		}
+	}
+}
+
+// compact rewrites the file with the pending entries only.
+func (s *FileOutboxStore) compact() error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	w := bufio.NewWriter(tmp)
+	for _, e := range s.sorted() {
+		if err := writeOutboxRecord(w, e); err != nil {
+			tmp.Close()
+			return err
+		}
+	}
+	if err := w.Flush(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func writeOutboxRecord(w io.Writer, rec any) error {
+	b, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(append(b, '\n'))
+	return err
+}
+
+func (s *FileOutboxStore) sorted() []OutboxEntry {
+	entries := make([]OutboxEntry, 0, len(s.pending))
+	for _, e := range s.pending {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Seq < entries[j].Seq
+	})
+	return entries
+}
+
+func (s *FileOutboxStore) write(rec any) error {
+	if s.f == nil {
+		return errOutboxClosed
+	}
+	if err := writeOutboxRecord(s.f, rec); err != nil {
+		return err
+	}
+	return s.f.Sync()
+}
+
+// Append durably stores the entry.
+func (s *FileOutboxStore) Append(e OutboxEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.write(e); err != nil {
+		return err
+	}
+	s.pending[e.Seq] = e
+	return nil
+}
+
+// Ack records the acknowledgement of the entry having the given sequence
+// number.
+func (s *FileOutboxStore) Ack(seq uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.pending[seq]; !ok {
+		return nil
+	}
+	if len(s.pending) == 1 {
+		if s.f == nil {
+			return errOutboxClosed
+		}
+		if err := s.f.Truncate(0); err != nil {
+			return err
+		}
+		if err := s.f.Sync(); err != nil {
+			return err
+		}
+	} else if err := s.write(outboxAck{Ack: seq}); err != nil {
+		return err
+	}
+	delete(s.pending, seq)
+	return nil
+}
+
+// Pending returns the entries which were not acknowledged, in order.
+func (s *FileOutboxStore) Pending() ([]OutboxEntry, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.sorted(), nil
+}
+
+// Close closes the file.
+func (s *FileOutboxStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.f == nil {
+		return nil
+	}
+	err := s.f.Close()
+	s.f = nil
+	return err
+}
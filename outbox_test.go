@@ -0,0 +1,188 @@
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// sent formats the recorded requests along with their bodies.
+func sent(requester *recordingRequester) []string {
+	var lines []string
+	for _, r := range requester.recorded() {
+		lines = append(lines, r.String()+" "+string(r.body))
+	}
+	return lines
+}
+
+func newOutboxClient(t *testing.T, requester stream.Requester) *stream.Client {
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(requester))
+	require.NoError(t, err)
+	return client
+}
+
+func TestOutbox(t *testing.T) {
+	requester := &recordingRequester{}
+	outbox, err := stream.NewOutbox(newOutboxClient(t, requester), &stream.MemoryOutboxStore{})
+	require.NoError(t, err)
+	defer outbox.Close()
+
+	now := stream.Time{Time: time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)}
+	user := stream.MustParseFeedID("user:1")
+	require.NoError(t, outbox.AddActivity(user, stream.Activity{Actor: "bob", Verb: "like", Object: "cake", ForeignID: "like:1", Time: now}))
+	require.NoError(t, outbox.AddToMany(stream.Activity{Actor: "bob", Verb: "eat", Object: "cake", ForeignID: "eat:1", Time: now}, user, stream.MustParseFeedID("user:2")))
+	require.NoError(t, outbox.FollowMany([]stream.FollowRelationship{{Source: "timeline:1", Target: "user:2"}}))
+	require.NoError(t, outbox.AddReaction(stream.AddReactionRequestObject{ID: "r1", Kind: "like", ActivityID: "a1", UserID: "bob"}))
+	require.NoError(t, outbox.UpsertCollection("food", stream.CollectionObject{ID: "cake", Data: map[string]any{"name": "cake"}}))
+	require.NoError(t, outbox.UpdateCollectionObject("food", "cake", map[string]any{"name": "pie"}))
+	require.NoError(t, outbox.DeleteCollectionObject("food", "cake"))
+
+	require.NoError(t, outbox.Flush(context.Background()))
+	assert.Equal(t, 0, outbox.Backlog())
+	assert.Equal(t, []string{
+		`POST /api/v1.0/feed/user/1/ {"actor":"bob","foreign_id":"like:1","object":"cake","time":"2024-01-02T03:04:05","verb":"like"}`,
+		`POST /api/v1.0/feed/add_to_many/ {"activity":{"actor":"bob","foreign_id":"eat:1","object":"cake","time":"2024-01-02T03:04:05","verb":"eat"},"feeds":["user:1","user:2"]}`,
+		`POST /api/v1.0/follow_many/ [{"source":"timeline:1","target":"user:2"}]`,
+		`POST /api/v1.0/reaction/ {"id":"r1","kind":"like","activity_id":"a1","user_id":"bob"}`,
+		`POST /api/v1.0/collections/ {"data":{"food":[{"id":"cake","name":"cake"}]}}`,
+		`PUT /api/v1.0/collections/food/cake/ {"data":{"name":"pie"}}`,
+		`DELETE /api/v1.0/collections/food/cake/ `,
+	}, sent(requester))
+}
+
+func TestOutboxRetry(t *testing.T) {
+	requester := &recordingRequester{respond: statusResponder(func(n int) int {
+		switch n {
+		case 1:
+			return 0
+		case 2:
+			return http.StatusServiceUnavailable
+		case 4:
+			return http.StatusBadRequest
+		default:
+			return http.StatusOK
+		}
+	})}
+	var (
+		mu       sync.Mutex
+		rejected []string
+	)
+	outbox, err := stream.NewOutbox(newOutboxClient(t, requester), &stream.MemoryOutboxStore{},
+		stream.WithOutboxRetryPolicy(stream.RetryPolicy{Backoff: time.Millisecond}),
+		stream.WithOutboxErrorHandler(func(e stream.OutboxEntry, err error) {
+			mu.Lock()
+			defer mu.Unlock()
+			rejected = append(rejected, e.Op)
+		}),
+	)
+	require.NoError(t, err)
+	defer outbox.Close()
+
+	require.NoError(t, outbox.DeleteCollectionObject("food", "1"))
+	require.NoError(t, outbox.DeleteCollectionObject("food", "2"))
+	require.NoError(t, outbox.DeleteCollectionObject("food", "3"))
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	require.NoError(t, outbox.Flush(ctx))
+
+	requests := requester.recorded()
+	require.Len(t, requests, 5)
+	assert.Contains(t, requests[2].path, "/food/1/")
+	assert.Contains(t, requests[3].path, "/food/2/")
+	assert.Contains(t, requests[4].path, "/food/3/")
+	assert.Equal(t, []string{stream.OutboxDeleteCollectionObject}, rejected)
+
+	require.NoError(t, outbox.Close())
+	assert.Error(t, outbox.AddReaction(stream.AddReactionRequestObject{}))
+}
+
+func TestOutboxReactionConflict(t *testing.T) {
+	// the reaction was added by a replay interrupted before being recorded
+	requester := &recordingRequester{respond: func(recordedRequest, int) (*http.Response, error) {
+		return errorResponse(http.StatusConflict, "reaction already exists"), nil
+	}}
+	var rejected []string
+	outbox, err := stream.NewOutbox(newOutboxClient(t, requester), &stream.MemoryOutboxStore{},
+		stream.WithOutboxErrorHandler(func(e stream.OutboxEntry, err error) {
+			rejected = append(rejected, e.Op)
+		}),
+	)
+	require.NoError(t, err)
+	defer outbox.Close()
+
+	require.NoError(t, outbox.AddReaction(stream.AddReactionRequestObject{Kind: "like", ActivityID: "a1", UserID: "bob"}))
+	require.NoError(t, outbox.Flush(context.Background()))
+	assert.Len(t, requester.recorded(), 1)
+	assert.Empty(t, rejected)
+	assert.Equal(t, 0, outbox.Backlog())
+}
+
+func TestFileOutboxStoreCrashRecovery(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+
+	// the first write succeeds, then Stream becomes unreachable
+	store, err := stream.OpenFileOutboxStore(path)
+	require.NoError(t, err)
+	requester := &recordingRequester{respond: statusResponder(func(n int) int {
+		if n == 1 {
+			return http.StatusOK
+		}
+		return 0
+	})}
+	outbox, err := stream.NewOutbox(newOutboxClient(t, requester), store,
+		stream.WithOutboxRetryPolicy(stream.RetryPolicy{Backoff: time.Hour}))
+	require.NoError(t, err)
+	require.NoError(t, outbox.DeleteCollectionObject("food", "1"))
+	require.Eventually(t, func() bool { return outbox.Backlog() == 0 }, 5*time.Second, time.Millisecond)
+	require.NoError(t, outbox.DeleteCollectionObject("food", "2"))
+	require.NoError(t, outbox.DeleteCollectionObject("food", "3"))
+	assert.Equal(t, 2, outbox.Backlog())
+
+	// the process crashes while appending an entry
+	f, err := os.OpenFile(path, os.O_WRONLY|os.O_APPEND, 0)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":4,"op":"delete_collecti`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	// the pending writes are replayed in order after restarting
+	store, err = stream.OpenFileOutboxStore(path)
+	require.NoError(t, err)
+	pending, err := store.Pending()
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+	assert.Equal(t, uint64(2), pending[0].Seq)
+
+	requester = &recordingRequester{}
+	restarted, err := stream.NewOutbox(newOutboxClient(t, requester), store)
+	require.NoError(t, err)
+	require.NoError(t, restarted.DeleteCollectionObject("food", "4"))
+	require.NoError(t, restarted.Flush(context.Background()))
+	assert.Equal(t, []string{
+		"DELETE /api/v1.0/collections/food/2/ ",
+		"DELETE /api/v1.0/collections/food/3/ ",
+		"DELETE /api/v1.0/collections/food/4/ ",
+	}, sent(requester))
+	require.NoError(t, restarted.Close())
+
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+	require.NoError(t, outbox.Close())
+}
+
+func TestFileOutboxStoreCorrupted(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "outbox.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte("{\"seq\":1}\nnot json\n{\"ack\":1}\n"), 0o600))
+	_, err := stream.OpenFileOutboxStore(path)
+	assert.Error(t, err)
+}
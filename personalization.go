@@ -2,9 +2,8 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
-	"fmt"
+	"net/http"
 )
 
 // PersonalizationClient is a specialized client for personalization features.
@@ -12,13 +11,10 @@ type PersonalizationClient struct {
 	client *Client
 }
 
-func (c *PersonalizationClient) decode(resp []byte, err error) (*PersonalizationResponse, error) {
-	if err != nil {
-		return nil, err
-	}
+func (c *PersonalizationClient) do(ctx context.Context, method string, endpoint endpoint, data any) (*PersonalizationResponse, error) {
 	var result PersonalizationResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, fmt.Errorf("cannot unmarshal resp: %w", err)
+	if err := c.client.request(ctx, method, endpoint, data, &result, c.client.authenticator.personalizationAuth); err != nil {
+		return nil, err
 	}
 	return &result, nil
 }
@@ -32,7 +28,7 @@ func (c *PersonalizationClient) Get(ctx context.Context, resource string, params
 	for k, v := range params {
 		endpoint.addQueryParam(makeRequestOption(k, v))
 	}
-	return c.decode(c.client.get(ctx, endpoint, nil, c.client.authenticator.personalizationAuth))
+	return c.do(ctx, http.MethodGet, endpoint, nil)
 }
 
 // Post sends data to the given resource, adding the given params to the request.
@@ -49,7 +45,7 @@ func (c *PersonalizationClient) Post(ctx context.Context, resource string, param
 			"data": data,
 		}
 	}
-	return c.decode(c.client.post(ctx, endpoint, data, c.client.authenticator.personalizationAuth))
+	return c.do(ctx, http.MethodPost, endpoint, data)
 }
 
 // Delete removes data from the given resource, adding the given params to the request.
@@ -61,5 +57,5 @@ func (c *PersonalizationClient) Delete(ctx context.Context, resource string, par
 	for k, v := range params {
 		endpoint.addQueryParam(makeRequestOption(k, v))
 	}
-	return c.decode(c.client.delete(ctx, endpoint, nil, c.client.authenticator.personalizationAuth))
+	return c.do(ctx, http.MethodDelete, endpoint, nil)
 }
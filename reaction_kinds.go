@@ -0,0 +1,243 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// NoReactionData is the data of reaction kinds carrying none, such as likes.
+type NoReactionData struct{}
+
+// ReactionKind is a kind of reaction whose data is of type T, which is
+// converted from and to the reaction data through its JSON encoding.
+type ReactionKind[T any] struct {
+	Name string
+}
+
+// NewReactionKind declares a reaction kind having the given name and data of
+// type T.
+func NewReactionKind[T any](name string) ReactionKind[T] {
+	return ReactionKind[T]{Name: name}
+}
+
+// TypedReaction is a reaction of a ReactionKind with its decoded data.
+type TypedReaction[T any] struct {
+	Reaction
+	Data T
+}
+
+// TypedEnrichedReaction is an enriched reaction of a ReactionKind with its
+// decoded data.
+type TypedEnrichedReaction[T any] struct {
+	*EnrichedReaction
+	Data T
+}
+
+// Encode converts the data to the generic reaction data.
+func (k ReactionKind[T]) Encode(data T) (map[string]any, error) {
+	b, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %s reaction data: %w", k.Name, err)
+	}
+	var m map[string]any
+	if err := json.Unmarshal(b, &m); err != nil {
+		return nil, fmt.Errorf("cannot encode %s reaction data: %w", k.Name, err)
+	}
+	if len(m) == 0 {
+		return nil, nil
+	}
+	return m, nil
+}
+
+// DecodeData converts the generic reaction data to T.
+func (k ReactionKind[T]) DecodeData(data map[string]any) (T, error) {
+	var v T
+	if len(data) == 0 {
+		return v, nil
+	}
+	b, err := json.Marshal(data)
+	if err == nil {
+		err = json.Unmarshal(b, &v)
+	}
+	if err != nil {
+		return v, fmt.Errorf("cannot decode %s reaction data: %w", k.Name, err)
+	}
+	return v, nil
+}
+
+// Request returns the request adding a reaction of this kind. Its ParentID
+// and TargetFeeds can be set before adding it.
+func (k ReactionKind[T]) Request(activityID, userID string, data T) (AddReactionRequestObject, error) {
+	m, err := k.Encode(data)
+	if err != nil {
+		return AddReactionRequestObject{}, err
+	}
+	return AddReactionRequestObject{Kind: k.Name, ActivityID: activityID, UserID: userID, Data: m}, nil
+}
+
+// Decode returns the typed version of a reaction of this kind.
+func (k ReactionKind[T]) Decode(r Reaction) (TypedReaction[T], error) {
+	if r.Kind != k.Name {
+		return TypedReaction[T]{}, fmt.Errorf("reaction %s is a %s, not a %s", r.ID, r.Kind, k.Name)
+	}
+	data, err := k.DecodeData(r.Data)
+	return TypedReaction[T]{Reaction: r, Data: data}, err
+}
+
+// Add adds a reaction of this kind to the activity.
+func (k ReactionKind[T]) Add(ctx context.Context, c ReactionsClientInterface, activityID, userID string, data T, targetFeeds ...string) (*TypedReaction[T], error) {
+	req, err := k.Request(activityID, userID, data)
+	if err != nil {
+		return nil, err
+	}
+	req.TargetFeeds = targetFeeds
+	resp, err := c.Add(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	return k.typed(resp.Reaction)
+}
+
+// AddChild adds a reaction of this kind as a child of the given reaction.
+func (k ReactionKind[T]) AddChild(ctx context.Context, c ReactionsClientInterface, parentID, userID string, data T, targetFeeds ...string) (*TypedReaction[T], error) {
+	req, err := k.Request("", userID, data)
+	if err != nil {
+		return nil, err
+	}
+	req.TargetFeeds = targetFeeds
+	resp, err := c.AddChild(ctx, parentID, req)
+	if err != nil {
+		return nil, err
+	}
+	return k.typed(resp.Reaction)
+}
+
+func (k ReactionKind[T]) typed(r Reaction) (*TypedReaction[T], error) {
+	if r.Kind == "" {
+		r.Kind = k.Name
+	}
+	typed, err := k.Decode(r)
+	if err != nil {
+		return nil, err
+	}
+	return &typed, nil
+}
+
+// Filter lists the reactions of this kind matching the attribute, such as
+// ByActivityID, with the given pagination. The response can be passed to
+// GetNextPageFilteredReactions, and its results to Decode.
+func (k ReactionKind[T]) Filter(ctx context.Context, c ReactionsClientInterface, attr FilterReactionsAttribute, opts ...FilterReactionsOption) ([]TypedReaction[T], *FilterReactionResponse, error) {
+	resp, err := c.Filter(ctx, attr.ByKind(k.Name), opts...)
+	if err != nil {
+		return nil, nil, err
+	}
+	reactions, err := k.DecodeAll(resp.Results)
+	return reactions, resp, err
+}
+
+// DecodeAll returns the typed versions of reactions of this kind.
+func (k ReactionKind[T]) DecodeAll(reactions []Reaction) ([]TypedReaction[T], error) {
+	typed := make([]TypedReaction[T], len(reactions))
+	for i, r := range reactions {
+		var err error
+		if typed[i], err = k.Decode(r); err != nil {
+			return nil, err
+		}
+	}
+	return typed, nil
+}
+
+// Latest returns the latest reactions of this kind of an activity enriched
+// with WithEnrichRecentReactions.
+func (k ReactionKind[T]) Latest(a EnrichedActivity) ([]TypedEnrichedReaction[T], error) {
+	return k.decodeEnriched(a.LatestReactions[k.Name])
+}
+
+// Own returns the reactions of this kind of the user to an activity enriched
+// with WithEnrichOwnReactions.
+func (k ReactionKind[T]) Own(a EnrichedActivity) ([]TypedEnrichedReaction[T], error) {
+	return k.decodeEnriched(a.OwnReactions[k.Name])
+}
+
+func (k ReactionKind[T]) decodeEnriched(reactions []*EnrichedReaction) ([]TypedEnrichedReaction[T], error) {
+	typed := make([]TypedEnrichedReaction[T], 0, len(reactions))
+	for _, r := range reactions {
+		data, err := k.DecodeData(r.Data)
+		if err != nil {
+			return nil, err
+		}
+		typed = append(typed, TypedEnrichedReaction[T]{EnrichedReaction: r, Data: data})
+	}
+	return typed, nil
+}
+
+// ReactionKinds is a registry of reaction kinds, used to decode reactions of
+// any kind at once. It's safe for concurrent use.
+type ReactionKinds struct {
+	mu       sync.RWMutex
+	decoders map[string]func(map[string]any) (any, error)
+}
+
+// NewReactionKinds returns an empty registry of reaction kinds.
+func NewReactionKinds() *ReactionKinds {
+	return &ReactionKinds{decoders: make(map[string]func(map[string]any) (any, error))}
+}
+
+// RegisterReactionKind declares a reaction kind having the given name and data
+// of type T in the registry, replacing any kind having the same name.
+func RegisterReactionKind[T any](r *ReactionKinds, name string) ReactionKind[T] {
+	k := NewReactionKind[T](name)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.decoders[name] = func(data map[string]any) (any, error) {
+		return k.DecodeData(data)
+	}
+	return k
+}
+
+// Known tells whether the kind is registered.
+func (r *ReactionKinds) Known(kind string) bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	_, ok := r.decoders[kind]
+	return ok
+}
+
+// DecodeData decodes the data of a reaction of the given kind: it's a value
+// of the registered type, or the raw data if the kind is unknown.
+func (r *ReactionKinds) DecodeData(kind string, data map[string]any) (any, error) {
+	r.mu.RLock()
+	decode, ok := r.decoders[kind]
+	r.mu.RUnlock()
+	if !ok {
+		return data, nil
+	}
+	return decode(data)
+}
+
+// DecodedReaction is an enriched reaction with its data decoded by a
+// ReactionKinds registry.
+type DecodedReaction struct {
+	*EnrichedReaction
+	// Data is a value of the registered type of the kind, or the raw data
+	// if the kind is unknown.
+	Data any
+}
+
+// Decode decodes enriched reactions by kind, such as the LatestReactions or
+// OwnReactions of an EnrichedActivity.
+func (r *ReactionKinds) Decode(reactions map[string][]*EnrichedReaction) (map[string][]DecodedReaction, error) {
+	decoded := make(map[string][]DecodedReaction, len(reactions))
+	for kind, list := range reactions {
+		for _, reaction := range list {
+			data, err := r.DecodeData(kind, reaction.Data)
+			if err != nil {
+				return nil, err
+			}
+			decoded[kind] = append(decoded[kind], DecodedReaction{EnrichedReaction: reaction, Data: data})
+		}
+	}
+	return decoded, nil
+}
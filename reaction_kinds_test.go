@@ -0,0 +1,115 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+	"github.com/GetStream/stream-go2/v8/streamtest"
+)
+
+type commentData struct {
+	Text     string   `json:"text"`
+	Mentions []string `json:"mentions,omitempty"`
+}
+
+func TestReactionKindAdd(t *testing.T) {
+	comment := stream.NewReactionKind[commentData]("comment")
+	like := stream.NewReactionKind[stream.NoReactionData]("like")
+
+	var added []stream.AddReactionRequestObject
+	fake := &streamtest.ReactionsClient{
+		AddFunc: func(_ context.Context, r stream.AddReactionRequestObject) (*stream.ReactionResponse, error) {
+			added = append(added, r)
+			resp := &stream.ReactionResponse{}
+			resp.AddReactionRequestObject = r
+			resp.ID = "r1"
+			return resp, nil
+		},
+		AddChildFunc: func(_ context.Context, parentID string, r stream.AddReactionRequestObject) (*stream.ReactionResponse, error) {
+			r.ParentID = parentID
+			added = append(added, r)
+			resp := &stream.ReactionResponse{}
+			resp.AddReactionRequestObject = r
+			return resp, nil
+		},
+	}
+
+	r, err := comment.Add(context.Background(), fake, "a1", "bob", commentData{Text: "hi"}, "notification:alice")
+	require.NoError(t, err)
+	assert.Equal(t, "r1", r.ID)
+	assert.Equal(t, commentData{Text: "hi"}, r.Data)
+	assert.Equal(t, stream.AddReactionRequestObject{
+		Kind:        "comment",
+		ActivityID:  "a1",
+		UserID:      "bob",
+		Data:        map[string]any{"text": "hi"},
+		TargetFeeds: []string{"notification:alice"},
+	}, added[0])
+
+	child, err := like.AddChild(context.Background(), fake, "r1", "alice", stream.NoReactionData{})
+	require.NoError(t, err)
+	assert.Equal(t, "r1", child.ParentID)
+	assert.Nil(t, added[1].Data)
+	assert.Equal(t, "like", added[1].Kind)
+}
+
+func TestReactionKindFilter(t *testing.T) {
+	comment := stream.NewReactionKind[commentData]("comment")
+	client, requester := newClient(t)
+	requester.resp = `{"results":[{"id":"r1","kind":"comment","data":{"text":"hi","mentions":["alice"]}}]}`
+
+	reactions, resp, err := comment.Filter(context.Background(), client.Reactions(), stream.ByActivityID("a1"), stream.WithLimit(5))
+	require.NoError(t, err)
+	testRequest(t, requester.req, http.MethodGet, "https://api.stream-io-api.com/api/v1.0/reaction/activity_id/a1/comment/?api_key=key&limit=5", "")
+	require.Len(t, reactions, 1)
+	assert.Equal(t, commentData{Text: "hi", Mentions: []string{"alice"}}, reactions[0].Data)
+	assert.Equal(t, "hi", reactions[0].Reaction.Data["text"])
+	assert.Len(t, resp.Results, 1)
+
+	_, err = comment.Decode(stream.Reaction{AddReactionRequestObject: stream.AddReactionRequestObject{Kind: "like"}})
+	assert.Error(t, err)
+	_, err = comment.DecodeData(map[string]any{"text": 42})
+	assert.Error(t, err)
+}
+
+func TestReactionKindsDecodeEnriched(t *testing.T) {
+	kinds := stream.NewReactionKinds()
+	comment := stream.RegisterReactionKind[commentData](kinds, "comment")
+	like := stream.RegisterReactionKind[stream.NoReactionData](kinds, "like")
+	assert.True(t, kinds.Known("like"))
+	assert.False(t, kinds.Known("repost"))
+
+	var activity stream.EnrichedActivity
+	require.NoError(t, json.Unmarshal([]byte(`{
+		"id": "a1",
+		"latest_reactions": {
+			"comment": [{"id": "r1", "kind": "comment", "data": {"text": "first"}}, {"id": "r2", "kind": "comment", "data": {"text": "second"}}],
+			"like": [{"id": "r3", "kind": "like"}],
+			"repost": [{"id": "r4", "kind": "repost", "data": {"via": "web"}}]
+		},
+		"own_reactions": {"like": [{"id": "r3", "kind": "like"}]}
+	}`), &activity))
+
+	comments, err := comment.Latest(activity)
+	require.NoError(t, err)
+	require.Len(t, comments, 2)
+	assert.Equal(t, "r2", comments[1].ID)
+	assert.Equal(t, "second", comments[1].Data.Text)
+
+	own, err := like.Own(activity)
+	require.NoError(t, err)
+	require.Len(t, own, 1)
+	assert.Equal(t, "r3", own[0].ID)
+
+	decoded, err := kinds.Decode(activity.LatestReactions)
+	require.NoError(t, err)
+	assert.Equal(t, commentData{Text: "first"}, decoded["comment"][0].Data)
+	assert.Equal(t, stream.NoReactionData{}, decoded["like"][0].Data)
+	assert.Equal(t, map[string]any{"via": "web"}, decoded["repost"][0].Data)
+}
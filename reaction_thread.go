@@ -0,0 +1,247 @@
+package stream
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+)
+
+const threadPageSize = 100
+
+// ChildrenCounts returns the ChildrenCounters of the reaction as counts by
+// kind, ignoring non-numeric values.
+func (r Reaction) ChildrenCounts() map[string]int {
+	counts := make(map[string]int, len(r.ChildrenCounters))
+	for kind, v := range r.ChildrenCounters {
+		switch n := v.(type) {
+		case float64:
+			counts[kind] = int(n)
+		case int:
+			counts[kind] = n
+		case json.Number:
+			if i, err := n.Int64(); err == nil {
+				counts[kind] = int(i)
+			}
+		}
+	}
+	return counts
+}
+
+// ThreadOrder is the ordering of the children of every reaction of a thread.
+type ThreadOrder int
+
+const (
+	// ThreadOrderOldest sorts children by creation time, oldest first.
+	ThreadOrderOldest ThreadOrder = iota
+	// ThreadOrderNewest sorts children by creation time, newest first.
+	ThreadOrderNewest
+	// ThreadOrderScore sorts children by score, highest first.
+	ThreadOrderScore
+)
+
+// ReactionNode is a reaction of a thread along with its loaded children.
+type ReactionNode struct {
+	Reaction Reaction
+	Parent   *ReactionNode
+	Children []*ReactionNode
+	// Counts is the total number of children by kind, including the ones
+	// which were not loaded.
+	Counts map[string]int
+	// Depth is the distance from the root of the thread.
+	Depth int
+}
+
+// childless tells whether the counts show that the node has no children of
+// the given kind, or of any kind if empty.
+func (n *ReactionNode) childless(kind string) bool {
+	if len(n.Counts) == 0 {
+		return false
+	}
+	if kind != "" {
+		return n.Counts[kind] == 0
+	}
+	for _, count := range n.Counts {
+		if count > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Walk calls fn with the node and its descendants, depth-first in order.
+// The children of a node are skipped when fn returns false for it.
+func (n *ReactionNode) Walk(fn func(*ReactionNode) bool) {
+	if !fn(n) {
+		return
+	}
+	for _, child := range n.Children {
+		child.Walk(fn)
+	}
+}
+
+// Flatten returns the node and its descendants, depth-first in order.
+func (n *ReactionNode) Flatten() []*ReactionNode {
+	var nodes []*ReactionNode
+	n.Walk(func(node *ReactionNode) bool {
+		nodes = append(nodes, node)
+		return true
+	})
+	return nodes
+}
+
+// ReactionThread is a tree of reactions returned by ReactionsClient.GetThread.
+type ReactionThread struct {
+	Root *ReactionNode
+	// Size is the number of loaded reactions, including the root.
+	Size int
+	// Truncated tells whether the size limit prevented loading all the
+	// children within the depth limit.
+	Truncated bool
+}
+
+// Walk calls fn with every reaction of the thread, depth-first in order.
+// The children of a node are skipped when fn returns false for it.
+func (t *ReactionThread) Walk(fn func(*ReactionNode) bool) {
+	t.Root.Walk(fn)
+}
+
+// Flatten returns the reactions of the thread, depth-first in order.
+func (t *ReactionThread) Flatten() []*ReactionNode {
+	return t.Root.Flatten()
+}
+
+// ThreadOption configures ReactionsClient.GetThread.
+type ThreadOption func(*threadOptions)
+
+type threadOptions struct {
+	maxSize int
+	kind    string
+	order   ThreadOrder
+	filter  []FilterReactionsOption
+}
+
+// WithThreadMaxSize limits the number of loaded reactions, including the
+// root. Levels closer to the root are loaded first.
+func WithThreadMaxSize(size int) ThreadOption {
+	return func(o *threadOptions) {
+		o.maxSize = size
+	}
+}
+
+// WithThreadKind only loads the children of the given kind.
+func WithThreadKind(kind string) ThreadOption {
+	return func(o *threadOptions) {
+		o.kind = kind
+	}
+}
+
+// WithThreadOrder sets the ordering of the children, defaulting to
+// ThreadOrderOldest.
+func WithThreadOrder(order ThreadOrder) ThreadOption {
+	return func(o *threadOptions) {
+		o.order = order
+	}
+}
+
+// WithThreadFilterOptions adds the given options to every Filter call, such
+// as WithOwnUserID or WithRanking.
+func WithThreadFilterOptions(opts ...FilterReactionsOption) ThreadOption {
+	return func(o *threadOptions) {
+		o.filter = append(o.filter, opts...)
+	}
+}
+
+// GetThread retrieves the reaction having the given ID along with its
+// children, recursively paging them up to the given depth below the root.
+// A zero or negative depth loads all the levels.
+func (c *ReactionsClient) GetThread(ctx context.Context, rootID string, depth int, opts ...ThreadOption) (*ReactionThread, error) {
+	o := threadOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	root, err := c.Get(ctx, rootID)
+	if err != nil {
+		return nil, err
+	}
+	thread := &ReactionThread{
+		Root: &ReactionNode{Reaction: root.Reaction, Counts: root.ChildrenCounts()},
+		Size: 1,
+	}
+	level := []*ReactionNode{thread.Root}
+	for d := 1; len(level) > 0 && (depth <= 0 || d <= depth); d++ {
+		var next []*ReactionNode
+		for _, parent := range level {
+			if parent.childless(o.kind) {
+				continue
+			}
+			full, err := c.loadChildren(ctx, thread, parent, o)
+			if err != nil {
+				return nil, err
+			}
+			next = append(next, parent.Children...)
+			if !full {
+				thread.Truncated = true
+				return thread, nil
+			}
+		}
+		level = next
+	}
+	return thread, nil
+}
+
+// loadChildren pages the children of the parent node, sorting them. It
+// returns false if the size limit was reached before loading all of them.
+func (c *ReactionsClient) loadChildren(ctx context.Context, thread *ReactionThread, parent *ReactionNode, o threadOptions) (bool, error) {
+	attr := ByReactionID(parent.Reaction.ID)
+	if o.kind != "" {
+		attr = attr.ByKind(o.kind)
+	}
+	full := true
+	resp, err := c.Filter(ctx, attr, append([]FilterReactionsOption{WithLimit(threadPageSize)}, o.filter...)...)
+	for err == nil {
+		for _, r := range resp.Results {
+			if o.maxSize > 0 && thread.Size >= o.maxSize {
+				full = false
+				break
+			}
+			parent.Children = append(parent.Children, &ReactionNode{
+				Reaction: r,
+				Parent:   parent,
+				Counts:   r.ChildrenCounts(),
+				Depth:    parent.Depth + 1,
+			})
+			thread.Size++
+		}
+		if !full || resp.Next == "" {
+			break
+		}
+		resp, err = c.GetNextPageFilteredReactions(ctx, resp)
+	}
+	if err != nil {
+		return false, err
+	}
+	sortReactionNodes(parent.Children, o.order)
+	return full, nil
+}
+
+func sortReactionNodes(nodes []*ReactionNode, order ThreadOrder) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		a, b := nodes[i].Reaction, nodes[j].Reaction
+		switch order {
+		case ThreadOrderNewest:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.After(b.CreatedAt)
+			}
+		case ThreadOrderScore:
+			if a.Score != b.Score {
+				return a.Score > b.Score
+			}
+		default:
+			if !a.CreatedAt.Equal(b.CreatedAt) {
+				return a.CreatedAt.Before(b.CreatedAt)
+			}
+		}
+		return a.ID < b.ID
+	})
+}
@@ -0,0 +1,145 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// reactionTree is a fake reactions backend paging children two at a time.
+type reactionTree struct {
+	reactions map[string]stream.Reaction
+	requests  int
+}
+
+func (tr *reactionTree) add(id, parent, kind string, minute int, score float64) {
+	r := stream.Reaction{CreatedAt: time.Date(2024, 1, 1, 0, minute, 0, 0, time.UTC), Score: score}
+	r.ID, r.ParentID, r.Kind = id, parent, kind
+	tr.reactions[id] = r
+	if parent != "" {
+		p := tr.reactions[parent]
+		if p.ChildrenCounters == nil {
+			p.ChildrenCounters = map[string]any{}
+		}
+		n, _ := p.ChildrenCounters[kind].(float64)
+		p.ChildrenCounters[kind] = n + 1
+		tr.reactions[parent] = p
+	}
+}
+
+func (tr *reactionTree) Do(req *http.Request) (*http.Response, error) {
+	tr.requests++
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(req.URL.Path, "/api/v1.0/"), "/"), "/")
+	respond := func(v any) (*http.Response, error) {
+		b, _ := json.Marshal(v)
+		return jsonResponse(http.StatusOK, string(b)), nil
+	}
+	if len(parts) == 2 {
+		r, ok := tr.reactions[parts[1]]
+		if !ok {
+			return jsonResponse(http.StatusNotFound, `{"detail":"not found","status_code":404}`), nil
+		}
+		return respond(r)
+	}
+	var children []stream.Reaction
+	for _, r := range tr.reactions {
+		if r.ParentID == parts[2] && (len(parts) < 4 || r.Kind == parts[3]) {
+			children = append(children, r)
+		}
+	}
+	sort.Slice(children, func(i, j int) bool { return children[i].ID > children[j].ID })
+	idLT := req.URL.Query().Get("id_lt")
+	var page []stream.Reaction
+	for _, r := range children {
+		if (idLT == "" || r.ID < idLT) && len(page) < 2 {
+			page = append(page, r)
+		}
+	}
+	next := ""
+	if len(page) == 2 && page[1].ID > children[len(children)-1].ID {
+		next = fmt.Sprintf("/api/v1.0/%s/?id_lt=%s&limit=2", strings.Join(parts, "/"), page[1].ID)
+	}
+	return respond(map[string]any{"results": page, "next": next})
+}
+
+func newReactionTree() *reactionTree {
+	tr := &reactionTree{reactions: map[string]stream.Reaction{}}
+	tr.add("root", "", "comment", 0, 0)
+	tr.add("c1", "root", "comment", 3, 1)
+	tr.add("c2", "root", "comment", 1, 5)
+	tr.add("c3", "root", "like", 2, 3)
+	tr.add("c11", "c1", "comment", 4, 0)
+	tr.add("c111", "c11", "comment", 5, 0)
+	tr.add("c21", "c2", "like", 6, 0)
+	return tr
+}
+
+func ids(nodes []*stream.ReactionNode) []string {
+	var out []string
+	for _, n := range nodes {
+		out = append(out, n.Reaction.ID)
+	}
+	return out
+}
+
+func TestGetThread(t *testing.T) {
+	tree := newReactionTree()
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(tree))
+	require.NoError(t, err)
+	reactions := client.Reactions()
+
+	thread, err := reactions.GetThread(context.Background(), "root", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 7, thread.Size)
+	assert.False(t, thread.Truncated)
+	assert.Equal(t, []string{"root", "c2", "c21", "c3", "c1", "c11", "c111"}, ids(thread.Flatten()))
+	assert.Equal(t, map[string]int{"comment": 2, "like": 1}, thread.Root.Counts)
+	deepest := thread.Root.Children[2].Children[0].Children[0]
+	assert.Equal(t, 3, deepest.Depth)
+	assert.Equal(t, "c1", deepest.Parent.Parent.Reaction.ID)
+	// the root children take two pages and the leaves have no counters
+	assert.Equal(t, 1+2+6, tree.requests)
+
+	var walked []string
+	thread.Walk(func(n *stream.ReactionNode) bool {
+		walked = append(walked, n.Reaction.ID)
+		return n.Reaction.ID != "c1"
+	})
+	assert.Equal(t, []string{"root", "c2", "c21", "c3", "c1"}, walked)
+
+	thread, err = reactions.GetThread(context.Background(), "root", 1, stream.WithThreadOrder(stream.ThreadOrderScore))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "c2", "c3", "c1"}, ids(thread.Flatten()))
+	assert.Empty(t, thread.Root.Children[2].Children)
+
+	thread, err = reactions.GetThread(context.Background(), "root", 0, stream.WithThreadOrder(stream.ThreadOrderNewest), stream.WithThreadMaxSize(5))
+	require.NoError(t, err)
+	assert.True(t, thread.Truncated)
+	assert.Equal(t, 5, thread.Size)
+	assert.Equal(t, []string{"root", "c1", "c11", "c3", "c2"}, ids(thread.Flatten()))
+
+	tree.requests = 0
+	thread, err = reactions.GetThread(context.Background(), "root", 0, stream.WithThreadKind("comment"))
+	require.NoError(t, err)
+	assert.Equal(t, []string{"root", "c2", "c1", "c11", "c111"}, ids(thread.Flatten()))
+	// c2 has no comment counted, so it isn't queried
+	assert.Equal(t, 1+4, tree.requests)
+
+	_, err = reactions.GetThread(context.Background(), "missing", 0)
+	assert.Error(t, err)
+}
+
+func TestReactionChildrenCounts(t *testing.T) {
+	r := stream.Reaction{ChildrenCounters: map[string]any{"like": float64(3), "comment": json.Number("2"), "bad": "x"}}
+	assert.Equal(t, map[string]int{"like": 3, "comment": 2}, r.ChildrenCounts())
+}
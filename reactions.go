@@ -2,9 +2,9 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 )
 
 // ReactionsClient is a specialized client used to interact with the Reactions endpoints.
@@ -27,38 +27,57 @@ func (c *ReactionsClient) AddChild(ctx context.Context, parentID string, r AddRe
 }
 
 func (c *ReactionsClient) addReaction(ctx context.Context, r AddReactionRequestObject) (*ReactionResponse, error) {
+	v, err := c.client.screenReaction(ctx, r)
+	if err != nil {
+		return nil, err
+	}
 	endpoint := c.client.makeEndpoint("reaction/")
-	return c.decode(c.client.post(ctx, endpoint, r, c.client.authenticator.reactionsAuth))
-}
-
-func (c *ReactionsClient) decode(resp []byte, err error) (*ReactionResponse, error) {
+	resp, err := c.do(ctx, http.MethodPost, endpoint, r)
 	if err != nil {
 		return nil, err
 	}
+	c.client.flagReaction(ctx, resp.ID, v)
+	return resp, nil
+}
 
+func (c *ReactionsClient) do(ctx context.Context, method string, endpoint endpoint, data any) (*ReactionResponse, error) {
 	var result ReactionResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := c.client.request(ctx, method, endpoint, data, &result, c.client.authenticator.reactionsAuth); err != nil {
 		return nil, err
 	}
 	return &result, nil
 }
 
+// UpdateWithFeedIDs is like Update, taking the target feeds as FeedIDs.
+func (c *ReactionsClient) UpdateWithFeedIDs(ctx context.Context, id string, data map[string]any, targetFeeds []FeedID) (*ReactionResponse, error) {
+	return c.Update(ctx, id, data, FeedIDStrings(targetFeeds...))
+}
+
 // Update updates the reaction's data and/or target feeds.
 func (c *ReactionsClient) Update(ctx context.Context, id string, data map[string]any, targetFeeds []string) (*ReactionResponse, error) {
+	v, err := c.client.screenReaction(ctx, AddReactionRequestObject{ID: id, Data: data, TargetFeeds: targetFeeds})
+	if err != nil {
+		return nil, err
+	}
 	endpoint := c.client.makeEndpoint("reaction/%s/", id)
 
 	reqData := map[string]any{
 		"data":         data,
 		"target_feeds": targetFeeds,
 	}
-	return c.decode(c.client.put(ctx, endpoint, reqData, c.client.authenticator.reactionsAuth))
+	resp, err := c.do(ctx, http.MethodPut, endpoint, reqData)
+	if err != nil {
+		return nil, err
+	}
+	c.client.flagReaction(ctx, id, v)
+	return resp, nil
 }
 
 // Get retrieves a reaction having the given id.
 func (c *ReactionsClient) Get(ctx context.Context, id string) (*ReactionResponse, error) {
 	endpoint := c.client.makeEndpoint("reaction/%s/", id)
 
-	return c.decode(c.client.get(ctx, endpoint, nil, c.client.authenticator.reactionsAuth))
+	return c.do(ctx, http.MethodGet, endpoint, nil)
 }
 
 // Delete deletes a reaction having the given id.
@@ -73,7 +92,7 @@ func (c *ReactionsClient) Delete(ctx context.Context, id string, opts ...Reactio
 		endpoint.addQueryParam(opt)
 	}
 
-	return c.decode(c.client.delete(ctx, endpoint, nil, c.client.authenticator.reactionsAuth))
+	return c.do(ctx, http.MethodDelete, endpoint, nil)
 }
 
 // SoftDelete soft-deletes a reaction having the given id. It is possible to restore this reaction using ReactionsClient.Restore.
@@ -87,8 +106,7 @@ func (c *ReactionsClient) SoftDelete(ctx context.Context, id string, opts ...Rea
 		endpoint.addQueryParam(opt)
 	}
 
-	_, err := c.client.delete(ctx, endpoint, nil, c.client.authenticator.reactionsAuth)
-	return err
+	return c.client.delete(ctx, endpoint, nil, nil, c.client.authenticator.reactionsAuth)
 }
 
 // Restore restores a soft deleted reaction having the given id.
@@ -101,8 +119,7 @@ func (c *ReactionsClient) Restore(ctx context.Context, id string, opts ...Reacti
 		endpoint.addQueryParam(opt)
 	}
 
-	_, err := c.client.put(ctx, endpoint, nil, c.client.authenticator.reactionsAuth)
-	return err
+	return c.client.put(ctx, endpoint, nil, nil, c.client.authenticator.reactionsAuth)
 }
 
 // Filter lists reactions based on the provided criteria and with the specified pagination.
@@ -114,12 +131,8 @@ func (c *ReactionsClient) Filter(ctx context.Context, attr FilterReactionsAttrib
 		endpoint.addQueryParam(opt)
 	}
 
-	resp, err := c.client.get(ctx, endpoint, nil, c.client.authenticator.reactionsAuth)
-	if err != nil {
-		return nil, err
-	}
 	var result FilterReactionResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := c.client.get(ctx, endpoint, nil, &result, c.client.authenticator.reactionsAuth); err != nil {
 		return nil, err
 	}
 	result.meta.attr = attr
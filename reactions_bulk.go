@@ -0,0 +1,118 @@
+package stream
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+	"net/http"
+)
+
+// ContentReactionID derives a UUID from a hash of the kind, activity, user,
+// parent and data of the reaction, so that adding the same reaction twice
+// gives it the same ID. It's used by ReactionsClient.AddMany for the
+// reactions lacking an ID.
+func ContentReactionID(r AddReactionRequestObject) string {
+	b, _ := json.Marshal(struct {
+		Kind       string         `json:"kind"`
+		ActivityID string         `json:"activity_id"`
+		UserID     string         `json:"user_id"`
+		ParentID   string         `json:"parent"`
+		Data       map[string]any `json:"data"`
+	}{r.Kind, r.ActivityID, r.UserID, r.ParentID, r.Data})
+	sum := sha256.Sum256(b)
+	sum[6] = sum[6]&0x0f | 0x50
+	sum[8] = sum[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", sum[0:4], sum[4:6], sum[6:8], sum[8:10], sum[10:16])
+}
+
+// ReactionUpdate is a change of the data and target feeds of a reaction,
+// used by ReactionsClient.UpdateMany.
+type ReactionUpdate struct {
+	ID          string
+	Data        map[string]any
+	TargetFeeds []string
+}
+
+// AddTargetFeeds adds the given feeds to the target feeds of the update.
+func (u *ReactionUpdate) AddTargetFeeds(ids ...FeedID) {
+	u.TargetFeeds = append(u.TargetFeeds, FeedIDStrings(ids...)...)
+}
+
+// AddMany adds any number of reactions, either top-level or child ones,
+// performing one API call per reaction concurrently. Reactions lacking an ID
+// get one from ContentReactionID, and reactions conflicting with an existing
+// one having the same ID are considered added, so that AddMany can safely be
+// retried. The BatchResult reports the outcome of every reaction, identified
+// by its ID.
+func (c *ReactionsClient) AddMany(ctx context.Context, reactions []AddReactionRequestObject, opts ...BatchOption) (*BatchResult, error) {
+	prepared := make([]AddReactionRequestObject, len(reactions))
+	for i, r := range reactions {
+		if r.ID == "" {
+			r.ID = ContentReactionID(r)
+		}
+		prepared[i] = r
+	}
+	result := runBatch(ctx, len(prepared), 1, func(i int) string { return prepared[i].ID }, opts, func(ctx context.Context, start, _ int) error {
+		if _, err := c.addReaction(ctx, prepared[start]); err != nil && !isConflict(err) {
+			return err
+		}
+		return nil
+	})
+	return result, result.Err()
+}
+
+// isConflict tells whether err is a 409 API error, meaning that a reaction
+// having the same ID was already added.
+func isConflict(err error) bool {
+	apiErr, ok := ToAPIError(err)
+	return ok && apiErr.StatusCode == http.StatusConflict
+}
+
+// UpdateMany updates any number of reactions, performing one API call per
+// reaction concurrently. The BatchResult reports the outcome of every
+// reaction.
+func (c *ReactionsClient) UpdateMany(ctx context.Context, updates []ReactionUpdate, opts ...BatchOption) (*BatchResult, error) {
+	result := runBatch(ctx, len(updates), 1, func(i int) string { return updates[i].ID }, opts, func(ctx context.Context, start, _ int) error {
+		u := updates[start]
+		_, err := c.Update(ctx, u.ID, u.Data, u.TargetFeeds)
+		return err
+	})
+	return result, result.Err()
+}
+
+// DeleteMany permanently deletes the reactions having the given IDs,
+// performing one API call per reaction concurrently. Reactions which are not
+// found are considered deleted.
+func (c *ReactionsClient) DeleteMany(ctx context.Context, ids []string, opts ...BatchOption) (*BatchResult, error) {
+	return c.forEachID(ctx, ids, opts, func(ctx context.Context, id string) error {
+		if _, err := c.Delete(ctx, id); err != nil && !isNotFound(err) {
+			return err
+		}
+		return nil
+	})
+}
+
+// SoftDeleteMany soft-deletes the reactions having the given IDs, performing
+// one API call per reaction concurrently. They can be restored with
+// RestoreMany.
+func (c *ReactionsClient) SoftDeleteMany(ctx context.Context, ids []string, opts ...BatchOption) (*BatchResult, error) {
+	return c.forEachID(ctx, ids, opts, func(ctx context.Context, id string) error {
+		return c.SoftDelete(ctx, id)
+	})
+}
+
+// RestoreMany restores the soft-deleted reactions having the given IDs,
+// performing one API call per reaction concurrently.
+func (c *ReactionsClient) RestoreMany(ctx context.Context, ids []string, opts ...BatchOption) (*BatchResult, error) {
+	return c.forEachID(ctx, ids, opts, func(ctx context.Context, id string) error {
+		return c.Restore(ctx, id)
+	})
+}
+
+func (c *ReactionsClient) forEachID(ctx context.Context, ids []string, opts []BatchOption, fn func(ctx context.Context, id string) error) (*BatchResult, error) {
+	result := runBatch(ctx, len(ids), 1, func(i int) string { return ids[i] }, opts, func(ctx context.Context, start, _ int) error {
+		return fn(ctx, ids[start])
+	})
+	return result, result.Err()
+}
@@ -0,0 +1,103 @@
+package stream_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestContentReactionID(t *testing.T) {
+	like := stream.AddReactionRequestObject{Kind: "like", ActivityID: "a1", UserID: "u1"}
+	id := stream.ContentReactionID(like)
+	assert.Regexp(t, `^[0-9a-f]{8}-[0-9a-f]{4}-5[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`, id)
+	like.TargetFeeds = []string{"user:u1"}
+	assert.Equal(t, id, stream.ContentReactionID(like))
+	like.UserID = "u2"
+	assert.NotEqual(t, id, stream.ContentReactionID(like))
+}
+
+func TestReactionsAddMany(t *testing.T) {
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		var body map[string]any
+		_ = r.decode(&body)
+		switch body["user_id"] {
+		case "dup":
+			return errorResponse(http.StatusConflict, "exists"), nil
+		case "bad":
+			return errorResponse(http.StatusBadRequest, "oops"), nil
+		}
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})
+
+	reactions := []stream.AddReactionRequestObject{
+		{Kind: "like", ActivityID: "a1", UserID: "u1", TargetFeeds: []string{"notification:bob"}},
+		{ID: "given", Kind: "like", ActivityID: "a1", UserID: "u2"},
+		{Kind: "like", ActivityID: "a1", UserID: "dup", ParentID: "r1"},
+		{Kind: "like", ActivityID: "a1", UserID: "bad"},
+	}
+	result, err := client.Reactions().AddMany(context.Background(), reactions, stream.WithBatchConcurrency(2))
+	require.Error(t, err)
+	require.Len(t, requester.recorded(), 4)
+	assert.Len(t, result.Succeeded(), 3)
+	require.Len(t, result.Failed(), 1)
+	assert.Equal(t, 3, result.Failed()[0].Index)
+	assert.Equal(t, stream.ContentReactionID(reactions[0]), result.Items[0].ID)
+	assert.Equal(t, "given", result.Items[1].ID)
+	assert.Empty(t, reactions[0].ID)
+
+	for _, r := range requester.recorded() {
+		assert.Equal(t, "/api/v1.0/reaction/", r.path)
+		var body map[string]any
+		require.NoError(t, r.decode(&body))
+		if body["user_id"] == "u1" {
+			assert.Equal(t, result.Items[0].ID, body["id"])
+			assert.Equal(t, []any{"notification:bob"}, body["target_feeds"])
+		}
+		if body["user_id"] == "dup" {
+			assert.Equal(t, "r1", body["parent"])
+		}
+	}
+}
+
+func TestReactionsBulkUpdateAndDelete(t *testing.T) {
+	client, requester := newRecordingClient(t, func(r recordedRequest, _ int) (*http.Response, error) {
+		if strings.Contains(r.path, "/gone/") {
+			return errorResponse(http.StatusNotFound, "not found"), nil
+		}
+		return jsonResponse(http.StatusOK, `{}`), nil
+	})
+	reactions := client.Reactions()
+
+	result, err := reactions.UpdateMany(context.Background(), []stream.ReactionUpdate{
+		{ID: "r1", Data: map[string]any{"text": "edited"}, TargetFeeds: []string{"user:bob"}},
+	})
+	require.NoError(t, err)
+	assert.Len(t, result.Succeeded(), 1)
+	assert.Equal(t, []string{"PUT /api/v1.0/reaction/r1/"}, requester.paths())
+	assert.JSONEq(t, `{"data":{"text":"edited"},"target_feeds":["user:bob"]}`, string(requester.recorded()[0].body))
+
+	requester.reset()
+	result, err = reactions.DeleteMany(context.Background(), []string{"r1", "gone"})
+	require.NoError(t, err)
+	assert.Len(t, result.Succeeded(), 2)
+	assert.Len(t, requester.recorded(), 2)
+
+	requester.reset()
+	result, err = reactions.SoftDeleteMany(context.Background(), []string{"r1", "gone"}, stream.WithBatchConcurrency(1))
+	require.Error(t, err)
+	assert.Equal(t, "gone", result.Failed()[0].ID)
+	assert.Equal(t, http.MethodDelete, requester.recorded()[0].method)
+	assert.Equal(t, "true", requester.recorded()[0].query.Get("soft"))
+
+	requester.reset()
+	result, err = reactions.RestoreMany(context.Background(), []string{"r1", "r2"})
+	require.NoError(t, err)
+	assert.Len(t, result.Succeeded(), 2)
+	assert.ElementsMatch(t, []string{"PUT /api/v1.0/reaction/r1/restore/", "PUT /api/v1.0/reaction/r2/restore/"}, requester.paths())
+}
@@ -0,0 +1,106 @@
+package stream
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+)
+
+const defaultRetryBackoff = 100 * time.Millisecond
+
+// RetryPolicy configures how API calls failing because of network errors, rate
+// limiting or server errors are retried. The zero value disables retries.
+// Rate limited calls are always retried, while the other failures are retried
+// only for idempotent calls: reads, PUT and DELETE calls, and calls carrying an
+// idempotency key (see WithCallIdempotencyKey). Activity additions are retried
+// safely with WithIdempotentWrites.
+type RetryPolicy struct {
+	// MaxRetries is the maximum number of retries after the first attempt.
+	MaxRetries int
+	// Backoff is the delay before the first retry, doubled at every following
+	// one. It defaults to 100ms.
+	Backoff time.Duration
+	// MaxBackoff caps the delay between retries, if positive.
+	MaxBackoff time.Duration
+}
+
+func (p RetryPolicy) delay(attempt int) time.Duration {
+	d := p.Backoff
+	if d <= 0 {
+		d = defaultRetryBackoff
+	}
+	for i := 0; i < attempt && d < time.Hour && (p.MaxBackoff <= 0 || d < p.MaxBackoff); i++ {
+		d *= 2
+	}
+	if p.MaxBackoff > 0 && d > p.MaxBackoff {
+		d = p.MaxBackoff
+	}
+	return d
+}
+
+func (p RetryPolicy) wait(ctx context.Context, attempt int) error {
+	t := time.NewTimer(p.delay(attempt))
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
+// transportError is returned when the request could not be performed at all,
+// for example because of a connection error.
+type transportError struct {
+	err error
+}
+
+func (e transportError) Error() string {
+	return "cannot perform request: " + e.err.Error()
+}
+
+func (e transportError) Unwrap() error {
+	return e.err
+}
+
+// canRetry tells whether the API call failing with err can be attempted
+// again. Rate limited calls weren't processed and are always retried, while
+// other failures are retried only for idempotent calls, since a failed write
+// may still have been applied.
+func canRetry(ctx context.Context, method string, header http.Header, err error) bool {
+	if !isRetryable(ctx, err) {
+		return false
+	}
+	if apiErr, ok := ToAPIError(err); ok && apiErr.StatusCode == http.StatusTooManyRequests {
+		return true
+	}
+	return isIdempotentCall(method, header)
+}
+
+// isIdempotentCall tells whether performing the call more than once has the
+// same effect as performing it once: reads, PUT and DELETE calls, and writes
+// carrying an Idempotency-Key header.
+func isIdempotentCall(method string, header http.Header) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	}
+	return header.Get(HeaderIdempotencyKey) != ""
+}
+
+// isRetryable tells whether the API call failing with err failed transiently,
+// so that it may succeed if attempted again.
+func isRetryable(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var terr transportError
+	if errors.As(err, &terr) {
+		return true
+	}
+	if apiErr, ok := ToAPIError(err); ok {
+		return apiErr.StatusCode == http.StatusTooManyRequests || apiErr.StatusCode >= http.StatusInternalServerError
+	}
+	return false
+}
@@ -0,0 +1,18 @@
+// Package streamtest provides fakes of the stream interfaces, to unit test
+// code depending on ClientInterface, the feed interfaces and the sub-client
+// interfaces without performing any API call.
+//
+// Every fake exposes a function field for each method of the interface, named
+// after the method with the Func suffix:
+//
+//	users := &streamtest.UsersClient{
+//		GetFunc: func(ctx context.Context, id string) (*stream.UserResponse, error) {
+//			return &stream.UserResponse{}, nil
+//		},
+//	}
+//	client := &streamtest.Client{
+//		UsersFunc: func() stream.UsersClientInterface { return users },
+//	}
+package streamtest
+
+//go:generate go run gen.go
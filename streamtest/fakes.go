@@ -0,0 +1,1643 @@
+// Code generated by gen.go; DO NOT EDIT.
+
+package streamtest
+
+import (
+	"context"
+	"io"
+	"time"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+var (
+	_ stream.ClientInterface                = (*Client)(nil)
+	_ stream.Feed                           = (*Feed)(nil)
+	_ stream.FlatFeedInterface              = (*FlatFeed)(nil)
+	_ stream.AggregatedFeedInterface        = (*AggregatedFeed)(nil)
+	_ stream.NotificationFeedInterface      = (*NotificationFeed)(nil)
+	_ stream.ReactionsClientInterface       = (*ReactionsClient)(nil)
+	_ stream.CollectionsClientInterface     = (*CollectionsClient)(nil)
+	_ stream.UsersClientInterface           = (*UsersClient)(nil)
+	_ stream.ModerationClientInterface      = (*ModerationClient)(nil)
+	_ stream.AnalyticsClientInterface       = (*AnalyticsClient)(nil)
+	_ stream.PersonalizationClientInterface = (*PersonalizationClient)(nil)
+	_ stream.AuditLogsClientInterface       = (*AuditLogsClient)(nil)
+)
+
+// Client is a fake stream.ClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type Client struct {
+	AddToManyFunc                        func(context.Context, stream.Activity, ...stream.Feed) error
+	AddToManyBatchedFunc                 func(context.Context, stream.Activity, []stream.Feed, ...stream.BatchOption) (*stream.BatchResult, error)
+	AggregatedFeedFunc                   func(string, string) (stream.AggregatedFeedInterface, error)
+	AggregatedFeedFromIDFunc             func(stream.FeedID) (stream.AggregatedFeedInterface, error)
+	AnalyticsFunc                        func() stream.AnalyticsClientInterface
+	AuditLogsFunc                        func() stream.AuditLogsClientInterface
+	BulkPartialUpdateActivitiesFunc      func(context.Context, []stream.UpdateActivityRequest, ...stream.BatchOption) (*stream.BatchResult, error)
+	BulkUpdateActivitiesFunc             func(context.Context, []stream.Activity, ...stream.BatchOption) (*stream.BatchResult, error)
+	CollectionsFunc                      func() stream.CollectionsClientInterface
+	CreateUserTokenFunc                  func(string) (string, error)
+	CreateUserTokenWithClaimsFunc        func(string, map[string]any) (string, error)
+	EraseUserFunc                        func(context.Context, string, stream.ErasurePlan, ...stream.EraseUserOption) (*stream.ErasureReport, error)
+	ExportActivitiesFunc                 func(context.Context, io.Writer, []stream.FeedID, ...stream.ExportActivitiesOption) (int, error)
+	ExportFollowsFunc                    func(context.Context, io.Writer, []stream.FeedID, ...stream.ExportFollowsOption) (int, error)
+	ExportUserDataFunc                   func(context.Context, string, stream.ErasurePlan, string) (*stream.UserDataManifest, error)
+	ExportUserDataZipFunc                func(context.Context, string, stream.ErasurePlan, io.Writer) (*stream.UserDataManifest, error)
+	FeedGroupFunc                        func(string) (stream.FeedGroup, bool)
+	FlatFeedFunc                         func(string, string) (stream.FlatFeedInterface, error)
+	FlatFeedFromIDFunc                   func(stream.FeedID) (stream.FlatFeedInterface, error)
+	FollowManyFunc                       func(context.Context, []stream.FollowRelationship, ...stream.FollowManyOption) error
+	FollowManyBatchedFunc                func(context.Context, []stream.FollowRelationship, ...stream.BatchOption) (*stream.BatchResult, error)
+	GenericFeedFunc                      func(string) (stream.Feed, error)
+	GetActivitiesByForeignIDFunc         func(context.Context, ...stream.ForeignIDTimePair) (*stream.GetActivitiesResponse, error)
+	GetActivitiesByIDFunc                func(context.Context, ...string) (*stream.GetActivitiesResponse, error)
+	GetEnrichedActivitiesByForeignIDFunc func(context.Context, []stream.ForeignIDTimePair, ...stream.GetActivitiesOption) (*stream.GetEnrichedActivitiesResponse, error)
+	GetEnrichedActivitiesByIDFunc        func(context.Context, []string, ...stream.GetActivitiesOption) (*stream.GetEnrichedActivitiesResponse, error)
+	GetReactionsFunc                     func(context.Context, []string, ...stream.GetReactionsOption) (*stream.GetReactionsByIDsResponse, error)
+	ImportActivitiesFunc                 func(context.Context, io.Reader, ...stream.ImportActivitiesOption) (int, error)
+	ImportFollowsFunc                    func(context.Context, io.Reader, ...stream.ImportFollowsOption) (int, error)
+	ModerationFunc                       func() stream.ModerationClientInterface
+	NotificationFeedFunc                 func(string, string) (stream.NotificationFeedInterface, error)
+	NotificationFeedFromIDFunc           func(stream.FeedID) (stream.NotificationFeedInterface, error)
+	PartialUpdateActivitiesFunc          func(context.Context, ...stream.UpdateActivityRequest) (*stream.UpdateActivitiesResponse, error)
+	PersonalizationFunc                  func() stream.PersonalizationClientInterface
+	ReactionsFunc                        func() stream.ReactionsClientInterface
+	RemoveActivitiesByForeignIDFunc      func(context.Context, stream.Feed, []string, ...stream.BatchOption) (*stream.BatchResult, error)
+	RemoveActivitiesByIDFunc             func(context.Context, stream.Feed, []string, ...stream.BatchOption) (*stream.BatchResult, error)
+	SyncFollowsFunc                      func(context.Context, stream.Feed, []stream.FeedID, ...stream.SyncFollowsOption) (*stream.SyncFollowsReport, error)
+	SyncFollowsManyFunc                  func(context.Context, map[stream.FeedID][]stream.FeedID, ...stream.SyncFollowsOption) ([]*stream.SyncFollowsReport, error)
+	UnfollowManyFunc                     func(context.Context, []stream.UnfollowRelationship) error
+	UnfollowManyBatchedFunc              func(context.Context, []stream.UnfollowRelationship, ...stream.BatchOption) (*stream.BatchResult, error)
+	UpdateActivitiesFunc                 func(context.Context, ...stream.Activity) (*stream.BaseResponse, error)
+	UpdateActivityByForeignIDFunc        func(context.Context, string, stream.Time, map[string]any, []string) (*stream.UpdateActivityResponse, error)
+	UpdateActivityByIDFunc               func(context.Context, string, map[string]any, []string) (*stream.UpdateActivityResponse, error)
+	UsersFunc                            func() stream.UsersClientInterface
+	WithTimeoutFunc                      func(time.Duration) stream.ClientInterface
+}
+
+// AddToMany calls AddToManyFunc.
+func (f *Client) AddToMany(a0 context.Context, a1 stream.Activity, a2 ...stream.Feed) error {
+	if f.AddToManyFunc == nil {
+		panic("streamtest: Client.AddToMany not implemented")
+	}
+	return f.AddToManyFunc(a0, a1, a2...)
+}
+
+// AddToManyBatched calls AddToManyBatchedFunc.
+func (f *Client) AddToManyBatched(a0 context.Context, a1 stream.Activity, a2 []stream.Feed, a3 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.AddToManyBatchedFunc == nil {
+		panic("streamtest: Client.AddToManyBatched not implemented")
+	}
+	return f.AddToManyBatchedFunc(a0, a1, a2, a3...)
+}
+
+// AggregatedFeed calls AggregatedFeedFunc.
+func (f *Client) AggregatedFeed(a0 string, a1 string) (stream.AggregatedFeedInterface, error) {
+	if f.AggregatedFeedFunc == nil {
+		panic("streamtest: Client.AggregatedFeed not implemented")
+	}
+	return f.AggregatedFeedFunc(a0, a1)
+}
+
+// AggregatedFeedFromID calls AggregatedFeedFromIDFunc.
+func (f *Client) AggregatedFeedFromID(a0 stream.FeedID) (stream.AggregatedFeedInterface, error) {
+	if f.AggregatedFeedFromIDFunc == nil {
+		panic("streamtest: Client.AggregatedFeedFromID not implemented")
+	}
+	return f.AggregatedFeedFromIDFunc(a0)
+}
+
+// Analytics calls AnalyticsFunc.
+func (f *Client) Analytics() stream.AnalyticsClientInterface {
+	if f.AnalyticsFunc == nil {
+		panic("streamtest: Client.Analytics not implemented")
+	}
+	return f.AnalyticsFunc()
+}
+
+// AuditLogs calls AuditLogsFunc.
+func (f *Client) AuditLogs() stream.AuditLogsClientInterface {
+	if f.AuditLogsFunc == nil {
+		panic("streamtest: Client.AuditLogs not implemented")
+	}
+	return f.AuditLogsFunc()
+}
+
+// BulkPartialUpdateActivities calls BulkPartialUpdateActivitiesFunc.
+func (f *Client) BulkPartialUpdateActivities(a0 context.Context, a1 []stream.UpdateActivityRequest, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.BulkPartialUpdateActivitiesFunc == nil {
+		panic("streamtest: Client.BulkPartialUpdateActivities not implemented")
+	}
+	return f.BulkPartialUpdateActivitiesFunc(a0, a1, a2...)
+}
+
+// BulkUpdateActivities calls BulkUpdateActivitiesFunc.
+func (f *Client) BulkUpdateActivities(a0 context.Context, a1 []stream.Activity, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.BulkUpdateActivitiesFunc == nil {
+		panic("streamtest: Client.BulkUpdateActivities not implemented")
+	}
+	return f.BulkUpdateActivitiesFunc(a0, a1, a2...)
+}
+
+// Collections calls CollectionsFunc.
+func (f *Client) Collections() stream.CollectionsClientInterface {
+	if f.CollectionsFunc == nil {
+		panic("streamtest: Client.Collections not implemented")
+	}
+	return f.CollectionsFunc()
+}
+
+// CreateUserToken calls CreateUserTokenFunc.
+func (f *Client) CreateUserToken(a0 string) (string, error) {
+	if f.CreateUserTokenFunc == nil {
+		panic("streamtest: Client.CreateUserToken not implemented")
+	}
+	return f.CreateUserTokenFunc(a0)
+}
+
+// CreateUserTokenWithClaims calls CreateUserTokenWithClaimsFunc.
+func (f *Client) CreateUserTokenWithClaims(a0 string, a1 map[string]any) (string, error) {
+	if f.CreateUserTokenWithClaimsFunc == nil {
+		panic("streamtest: Client.CreateUserTokenWithClaims not implemented")
+	}
+	return f.CreateUserTokenWithClaimsFunc(a0, a1)
+}
+
+// EraseUser calls EraseUserFunc.
+func (f *Client) EraseUser(a0 context.Context, a1 string, a2 stream.ErasurePlan, a3 ...stream.EraseUserOption) (*stream.ErasureReport, error) {
+	if f.EraseUserFunc == nil {
+		panic("streamtest: Client.EraseUser not implemented")
+	}
+	return f.EraseUserFunc(a0, a1, a2, a3...)
+}
+
+// ExportActivities calls ExportActivitiesFunc.
+func (f *Client) ExportActivities(a0 context.Context, a1 io.Writer, a2 []stream.FeedID, a3 ...stream.ExportActivitiesOption) (int, error) {
+	if f.ExportActivitiesFunc == nil {
+		panic("streamtest: Client.ExportActivities not implemented")
+	}
+	return f.ExportActivitiesFunc(a0, a1, a2, a3...)
+}
+
+// ExportFollows calls ExportFollowsFunc.
+func (f *Client) ExportFollows(a0 context.Context, a1 io.Writer, a2 []stream.FeedID, a3 ...stream.ExportFollowsOption) (int, error) {
+	if f.ExportFollowsFunc == nil {
+		panic("streamtest: Client.ExportFollows not implemented")
+	}
+	return f.ExportFollowsFunc(a0, a1, a2, a3...)
+}
+
+// ExportUserData calls ExportUserDataFunc.
+func (f *Client) ExportUserData(a0 context.Context, a1 string, a2 stream.ErasurePlan, a3 string) (*stream.UserDataManifest, error) {
+	if f.ExportUserDataFunc == nil {
+		panic("streamtest: Client.ExportUserData not implemented")
+	}
+	return f.ExportUserDataFunc(a0, a1, a2, a3)
+}
+
+// ExportUserDataZip calls ExportUserDataZipFunc.
+func (f *Client) ExportUserDataZip(a0 context.Context, a1 string, a2 stream.ErasurePlan, a3 io.Writer) (*stream.UserDataManifest, error) {
+	if f.ExportUserDataZipFunc == nil {
+		panic("streamtest: Client.ExportUserDataZip not implemented")
+	}
+	return f.ExportUserDataZipFunc(a0, a1, a2, a3)
+}
+
+// FeedGroup calls FeedGroupFunc.
+func (f *Client) FeedGroup(a0 string) (stream.FeedGroup, bool) {
+	if f.FeedGroupFunc == nil {
+		panic("streamtest: Client.FeedGroup not implemented")
+	}
+	return f.FeedGroupFunc(a0)
+}
+
+// FlatFeed calls FlatFeedFunc.
+func (f *Client) FlatFeed(a0 string, a1 string) (stream.FlatFeedInterface, error) {
+	if f.FlatFeedFunc == nil {
+		panic("streamtest: Client.FlatFeed not implemented")
+	}
+	return f.FlatFeedFunc(a0, a1)
+}
+
+// FlatFeedFromID calls FlatFeedFromIDFunc.
+func (f *Client) FlatFeedFromID(a0 stream.FeedID) (stream.FlatFeedInterface, error) {
+	if f.FlatFeedFromIDFunc == nil {
+		panic("streamtest: Client.FlatFeedFromID not implemented")
+	}
+	return f.FlatFeedFromIDFunc(a0)
+}
+
+// FollowMany calls FollowManyFunc.
+func (f *Client) FollowMany(a0 context.Context, a1 []stream.FollowRelationship, a2 ...stream.FollowManyOption) error {
+	if f.FollowManyFunc == nil {
+		panic("streamtest: Client.FollowMany not implemented")
+	}
+	return f.FollowManyFunc(a0, a1, a2...)
+}
+
+// FollowManyBatched calls FollowManyBatchedFunc.
+func (f *Client) FollowManyBatched(a0 context.Context, a1 []stream.FollowRelationship, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.FollowManyBatchedFunc == nil {
+		panic("streamtest: Client.FollowManyBatched not implemented")
+	}
+	return f.FollowManyBatchedFunc(a0, a1, a2...)
+}
+
+// GenericFeed calls GenericFeedFunc.
+func (f *Client) GenericFeed(a0 string) (stream.Feed, error) {
+	if f.GenericFeedFunc == nil {
+		panic("streamtest: Client.GenericFeed not implemented")
+	}
+	return f.GenericFeedFunc(a0)
+}
+
+// GetActivitiesByForeignID calls GetActivitiesByForeignIDFunc.
+func (f *Client) GetActivitiesByForeignID(a0 context.Context, a1 ...stream.ForeignIDTimePair) (*stream.GetActivitiesResponse, error) {
+	if f.GetActivitiesByForeignIDFunc == nil {
+		panic("streamtest: Client.GetActivitiesByForeignID not implemented")
+	}
+	return f.GetActivitiesByForeignIDFunc(a0, a1...)
+}
+
+// GetActivitiesByID calls GetActivitiesByIDFunc.
+func (f *Client) GetActivitiesByID(a0 context.Context, a1 ...string) (*stream.GetActivitiesResponse, error) {
+	if f.GetActivitiesByIDFunc == nil {
+		panic("streamtest: Client.GetActivitiesByID not implemented")
+	}
+	return f.GetActivitiesByIDFunc(a0, a1...)
+}
+
+// GetEnrichedActivitiesByForeignID calls GetEnrichedActivitiesByForeignIDFunc.
+func (f *Client) GetEnrichedActivitiesByForeignID(a0 context.Context, a1 []stream.ForeignIDTimePair, a2 ...stream.GetActivitiesOption) (*stream.GetEnrichedActivitiesResponse, error) {
+	if f.GetEnrichedActivitiesByForeignIDFunc == nil {
+		panic("streamtest: Client.GetEnrichedActivitiesByForeignID not implemented")
+	}
+	return f.GetEnrichedActivitiesByForeignIDFunc(a0, a1, a2...)
+}
+
+// GetEnrichedActivitiesByID calls GetEnrichedActivitiesByIDFunc.
+func (f *Client) GetEnrichedActivitiesByID(a0 context.Context, a1 []string, a2 ...stream.GetActivitiesOption) (*stream.GetEnrichedActivitiesResponse, error) {
+	if f.GetEnrichedActivitiesByIDFunc == nil {
+		panic("streamtest: Client.GetEnrichedActivitiesByID not implemented")
+	}
+	return f.GetEnrichedActivitiesByIDFunc(a0, a1, a2...)
+}
+
+// GetReactions calls GetReactionsFunc.
+func (f *Client) GetReactions(a0 context.Context, a1 []string, a2 ...stream.GetReactionsOption) (*stream.GetReactionsByIDsResponse, error) {
+	if f.GetReactionsFunc == nil {
+		panic("streamtest: Client.GetReactions not implemented")
+	}
+	return f.GetReactionsFunc(a0, a1, a2...)
+}
+
+// ImportActivities calls ImportActivitiesFunc.
+func (f *Client) ImportActivities(a0 context.Context, a1 io.Reader, a2 ...stream.ImportActivitiesOption) (int, error) {
+	if f.ImportActivitiesFunc == nil {
+		panic("streamtest: Client.ImportActivities not implemented")
+	}
+	return f.ImportActivitiesFunc(a0, a1, a2...)
+}
+
+// ImportFollows calls ImportFollowsFunc.
+func (f *Client) ImportFollows(a0 context.Context, a1 io.Reader, a2 ...stream.ImportFollowsOption) (int, error) {
+	if f.ImportFollowsFunc == nil {
+		panic("streamtest: Client.ImportFollows not implemented")
+	}
+	return f.ImportFollowsFunc(a0, a1, a2...)
+}
+
+// Moderation calls ModerationFunc.
+func (f *Client) Moderation() stream.ModerationClientInterface {
+	if f.ModerationFunc == nil {
+		panic("streamtest: Client.Moderation not implemented")
+	}
+	return f.ModerationFunc()
+}
+
+// NotificationFeed calls NotificationFeedFunc.
+func (f *Client) NotificationFeed(a0 string, a1 string) (stream.NotificationFeedInterface, error) {
+	if f.NotificationFeedFunc == nil {
+		panic("streamtest: Client.NotificationFeed not implemented")
+	}
+	return f.NotificationFeedFunc(a0, a1)
+}
+
+// NotificationFeedFromID calls NotificationFeedFromIDFunc.
+func (f *Client) NotificationFeedFromID(a0 stream.FeedID) (stream.NotificationFeedInterface, error) {
+	if f.NotificationFeedFromIDFunc == nil {
+		panic("streamtest: Client.NotificationFeedFromID not implemented")
+	}
+	return f.NotificationFeedFromIDFunc(a0)
+}
+
+// PartialUpdateActivities calls PartialUpdateActivitiesFunc.
+func (f *Client) PartialUpdateActivities(a0 context.Context, a1 ...stream.UpdateActivityRequest) (*stream.UpdateActivitiesResponse, error) {
+	if f.PartialUpdateActivitiesFunc == nil {
+		panic("streamtest: Client.PartialUpdateActivities not implemented")
+	}
+	return f.PartialUpdateActivitiesFunc(a0, a1...)
+}
+
+// Personalization calls PersonalizationFunc.
+func (f *Client) Personalization() stream.PersonalizationClientInterface {
+	if f.PersonalizationFunc == nil {
+		panic("streamtest: Client.Personalization not implemented")
+	}
+	return f.PersonalizationFunc()
+}
+
+// Reactions calls ReactionsFunc.
+func (f *Client) Reactions() stream.ReactionsClientInterface {
+	if f.ReactionsFunc == nil {
+		panic("streamtest: Client.Reactions not implemented")
+	}
+	return f.ReactionsFunc()
+}
+
+// RemoveActivitiesByForeignID calls RemoveActivitiesByForeignIDFunc.
+func (f *Client) RemoveActivitiesByForeignID(a0 context.Context, a1 stream.Feed, a2 []string, a3 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.RemoveActivitiesByForeignIDFunc == nil {
+		panic("streamtest: Client.RemoveActivitiesByForeignID not implemented")
+	}
+	return f.RemoveActivitiesByForeignIDFunc(a0, a1, a2, a3...)
+}
+
+// RemoveActivitiesByID calls RemoveActivitiesByIDFunc.
+func (f *Client) RemoveActivitiesByID(a0 context.Context, a1 stream.Feed, a2 []string, a3 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.RemoveActivitiesByIDFunc == nil {
+		panic("streamtest: Client.RemoveActivitiesByID not implemented")
+	}
+	return f.RemoveActivitiesByIDFunc(a0, a1, a2, a3...)
+}
+
+// SyncFollows calls SyncFollowsFunc.
+func (f *Client) SyncFollows(a0 context.Context, a1 stream.Feed, a2 []stream.FeedID, a3 ...stream.SyncFollowsOption) (*stream.SyncFollowsReport, error) {
+	if f.SyncFollowsFunc == nil {
+		panic("streamtest: Client.SyncFollows not implemented")
+	}
+	return f.SyncFollowsFunc(a0, a1, a2, a3...)
+}
+
+// SyncFollowsMany calls SyncFollowsManyFunc.
+func (f *Client) SyncFollowsMany(a0 context.Context, a1 map[stream.FeedID][]stream.FeedID, a2 ...stream.SyncFollowsOption) ([]*stream.SyncFollowsReport, error) {
+	if f.SyncFollowsManyFunc == nil {
+		panic("streamtest: Client.SyncFollowsMany not implemented")
+	}
+	return f.SyncFollowsManyFunc(a0, a1, a2...)
+}
+
+// UnfollowMany calls UnfollowManyFunc.
+func (f *Client) UnfollowMany(a0 context.Context, a1 []stream.UnfollowRelationship) error {
+	if f.UnfollowManyFunc == nil {
+		panic("streamtest: Client.UnfollowMany not implemented")
+	}
+	return f.UnfollowManyFunc(a0, a1)
+}
+
+// UnfollowManyBatched calls UnfollowManyBatchedFunc.
+func (f *Client) UnfollowManyBatched(a0 context.Context, a1 []stream.UnfollowRelationship, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.UnfollowManyBatchedFunc == nil {
+		panic("streamtest: Client.UnfollowManyBatched not implemented")
+	}
+	return f.UnfollowManyBatchedFunc(a0, a1, a2...)
+}
+
+// UpdateActivities calls UpdateActivitiesFunc.
+func (f *Client) UpdateActivities(a0 context.Context, a1 ...stream.Activity) (*stream.BaseResponse, error) {
+	if f.UpdateActivitiesFunc == nil {
+		panic("streamtest: Client.UpdateActivities not implemented")
+	}
+	return f.UpdateActivitiesFunc(a0, a1...)
+}
+
+// UpdateActivityByForeignID calls UpdateActivityByForeignIDFunc.
+func (f *Client) UpdateActivityByForeignID(a0 context.Context, a1 string, a2 stream.Time, a3 map[string]any, a4 []string) (*stream.UpdateActivityResponse, error) {
+	if f.UpdateActivityByForeignIDFunc == nil {
+		panic("streamtest: Client.UpdateActivityByForeignID not implemented")
+	}
+	return f.UpdateActivityByForeignIDFunc(a0, a1, a2, a3, a4)
+}
+
+// UpdateActivityByID calls UpdateActivityByIDFunc.
+func (f *Client) UpdateActivityByID(a0 context.Context, a1 string, a2 map[string]any, a3 []string) (*stream.UpdateActivityResponse, error) {
+	if f.UpdateActivityByIDFunc == nil {
+		panic("streamtest: Client.UpdateActivityByID not implemented")
+	}
+	return f.UpdateActivityByIDFunc(a0, a1, a2, a3)
+}
+
+// Users calls UsersFunc.
+func (f *Client) Users() stream.UsersClientInterface {
+	if f.UsersFunc == nil {
+		panic("streamtest: Client.Users not implemented")
+	}
+	return f.UsersFunc()
+}
+
+// WithTimeout calls WithTimeoutFunc.
+func (f *Client) WithTimeout(a0 time.Duration) stream.ClientInterface {
+	if f.WithTimeoutFunc == nil {
+		panic("streamtest: Client.WithTimeout not implemented")
+	}
+	return f.WithTimeoutFunc(a0)
+}
+
+// Feed is a fake stream.Feed. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type Feed struct {
+	AddActivitiesFunc             func(context.Context, ...stream.Activity) (*stream.AddActivitiesResponse, error)
+	AddActivityFunc               func(context.Context, stream.Activity) (*stream.AddActivityResponse, error)
+	BatchUpdateToTargetsFunc      func(context.Context, []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error)
+	FollowFunc                    func(context.Context, *stream.FlatFeed, ...stream.FollowFeedOption) (*stream.BaseResponse, error)
+	GetFollowingFunc              func(context.Context, ...stream.FollowingOption) (*stream.FollowingResponse, error)
+	IDFunc                        func() string
+	RealtimeTokenFunc             func(bool) string
+	RemoveActivityByForeignIDFunc func(context.Context, string) (*stream.RemoveActivityResponse, error)
+	RemoveActivityByIDFunc        func(context.Context, string, ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error)
+	SlugFunc                      func() string
+	UnfollowFunc                  func(context.Context, stream.Feed, ...stream.UnfollowOption) (*stream.BaseResponse, error)
+	UpdateToTargetsFunc           func(context.Context, stream.Activity, ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error)
+	UserIDFunc                    func() string
+}
+
+// AddActivities calls AddActivitiesFunc.
+func (f *Feed) AddActivities(a0 context.Context, a1 ...stream.Activity) (*stream.AddActivitiesResponse, error) {
+	if f.AddActivitiesFunc == nil {
+		panic("streamtest: Feed.AddActivities not implemented")
+	}
+	return f.AddActivitiesFunc(a0, a1...)
+}
+
+// AddActivity calls AddActivityFunc.
+func (f *Feed) AddActivity(a0 context.Context, a1 stream.Activity) (*stream.AddActivityResponse, error) {
+	if f.AddActivityFunc == nil {
+		panic("streamtest: Feed.AddActivity not implemented")
+	}
+	return f.AddActivityFunc(a0, a1)
+}
+
+// BatchUpdateToTargets calls BatchUpdateToTargetsFunc.
+func (f *Feed) BatchUpdateToTargets(a0 context.Context, a1 []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error) {
+	if f.BatchUpdateToTargetsFunc == nil {
+		panic("streamtest: Feed.BatchUpdateToTargets not implemented")
+	}
+	return f.BatchUpdateToTargetsFunc(a0, a1)
+}
+
+// Follow calls FollowFunc.
+func (f *Feed) Follow(a0 context.Context, a1 *stream.FlatFeed, a2 ...stream.FollowFeedOption) (*stream.BaseResponse, error) {
+	if f.FollowFunc == nil {
+		panic("streamtest: Feed.Follow not implemented")
+	}
+	return f.FollowFunc(a0, a1, a2...)
+}
+
+// GetFollowing calls GetFollowingFunc.
+func (f *Feed) GetFollowing(a0 context.Context, a1 ...stream.FollowingOption) (*stream.FollowingResponse, error) {
+	if f.GetFollowingFunc == nil {
+		panic("streamtest: Feed.GetFollowing not implemented")
+	}
+	return f.GetFollowingFunc(a0, a1...)
+}
+
+// ID calls IDFunc.
+func (f *Feed) ID() string {
+	if f.IDFunc == nil {
+		panic("streamtest: Feed.ID not implemented")
+	}
+	return f.IDFunc()
+}
+
+// RealtimeToken calls RealtimeTokenFunc.
+func (f *Feed) RealtimeToken(a0 bool) string {
+	if f.RealtimeTokenFunc == nil {
+		panic("streamtest: Feed.RealtimeToken not implemented")
+	}
+	return f.RealtimeTokenFunc(a0)
+}
+
+// RemoveActivityByForeignID calls RemoveActivityByForeignIDFunc.
+func (f *Feed) RemoveActivityByForeignID(a0 context.Context, a1 string) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByForeignIDFunc == nil {
+		panic("streamtest: Feed.RemoveActivityByForeignID not implemented")
+	}
+	return f.RemoveActivityByForeignIDFunc(a0, a1)
+}
+
+// RemoveActivityByID calls RemoveActivityByIDFunc.
+func (f *Feed) RemoveActivityByID(a0 context.Context, a1 string, a2 ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByIDFunc == nil {
+		panic("streamtest: Feed.RemoveActivityByID not implemented")
+	}
+	return f.RemoveActivityByIDFunc(a0, a1, a2...)
+}
+
+// Slug calls SlugFunc.
+func (f *Feed) Slug() string {
+	if f.SlugFunc == nil {
+		panic("streamtest: Feed.Slug not implemented")
+	}
+	return f.SlugFunc()
+}
+
+// Unfollow calls UnfollowFunc.
+func (f *Feed) Unfollow(a0 context.Context, a1 stream.Feed, a2 ...stream.UnfollowOption) (*stream.BaseResponse, error) {
+	if f.UnfollowFunc == nil {
+		panic("streamtest: Feed.Unfollow not implemented")
+	}
+	return f.UnfollowFunc(a0, a1, a2...)
+}
+
+// UpdateToTargets calls UpdateToTargetsFunc.
+func (f *Feed) UpdateToTargets(a0 context.Context, a1 stream.Activity, a2 ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error) {
+	if f.UpdateToTargetsFunc == nil {
+		panic("streamtest: Feed.UpdateToTargets not implemented")
+	}
+	return f.UpdateToTargetsFunc(a0, a1, a2...)
+}
+
+// UserID calls UserIDFunc.
+func (f *Feed) UserID() string {
+	if f.UserIDFunc == nil {
+		panic("streamtest: Feed.UserID not implemented")
+	}
+	return f.UserIDFunc()
+}
+
+// FlatFeed is a fake stream.FlatFeedInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type FlatFeed struct {
+	AddActivitiesFunc                    func(context.Context, ...stream.Activity) (*stream.AddActivitiesResponse, error)
+	AddActivityFunc                      func(context.Context, stream.Activity) (*stream.AddActivityResponse, error)
+	BatchUpdateToTargetsFunc             func(context.Context, []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error)
+	FeedIDFunc                           func() stream.FeedID
+	FollowFunc                           func(context.Context, *stream.FlatFeed, ...stream.FollowFeedOption) (*stream.BaseResponse, error)
+	FollowStatsFunc                      func(context.Context, ...stream.FollowStatOption) (*stream.FollowStatResponse, error)
+	GetActivitiesFunc                    func(context.Context, ...stream.GetActivitiesOption) (*stream.FlatFeedResponse, error)
+	GetActivitiesWithRankingFunc         func(context.Context, string, ...stream.GetActivitiesOption) (*stream.FlatFeedResponse, error)
+	GetEnrichedActivitiesFunc            func(context.Context, ...stream.GetActivitiesOption) (*stream.EnrichedFlatFeedResponse, error)
+	GetEnrichedActivitiesWithRankingFunc func(context.Context, string, ...stream.GetActivitiesOption) (*stream.EnrichedFlatFeedResponse, error)
+	GetFollowersFunc                     func(context.Context, ...stream.FollowersOption) (*stream.FollowersResponse, error)
+	GetFollowingFunc                     func(context.Context, ...stream.FollowingOption) (*stream.FollowingResponse, error)
+	GetNextPageActivitiesFunc            func(context.Context, *stream.FlatFeedResponse) (*stream.FlatFeedResponse, error)
+	GetNextPageEnrichedActivitiesFunc    func(context.Context, *stream.EnrichedFlatFeedResponse) (*stream.EnrichedFlatFeedResponse, error)
+	IDFunc                               func() string
+	RealtimeTokenFunc                    func(bool) string
+	RemoveActivityByForeignIDFunc        func(context.Context, string) (*stream.RemoveActivityResponse, error)
+	RemoveActivityByIDFunc               func(context.Context, string, ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error)
+	SlugFunc                             func() string
+	UnfollowFunc                         func(context.Context, stream.Feed, ...stream.UnfollowOption) (*stream.BaseResponse, error)
+	UpdateToTargetsFunc                  func(context.Context, stream.Activity, ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error)
+	UserIDFunc                           func() string
+}
+
+// AddActivities calls AddActivitiesFunc.
+func (f *FlatFeed) AddActivities(a0 context.Context, a1 ...stream.Activity) (*stream.AddActivitiesResponse, error) {
+	if f.AddActivitiesFunc == nil {
+		panic("streamtest: FlatFeed.AddActivities not implemented")
+	}
+	return f.AddActivitiesFunc(a0, a1...)
+}
+
+// AddActivity calls AddActivityFunc.
+func (f *FlatFeed) AddActivity(a0 context.Context, a1 stream.Activity) (*stream.AddActivityResponse, error) {
+	if f.AddActivityFunc == nil {
+		panic("streamtest: FlatFeed.AddActivity not implemented")
+	}
+	return f.AddActivityFunc(a0, a1)
+}
+
+// BatchUpdateToTargets calls BatchUpdateToTargetsFunc.
+func (f *FlatFeed) BatchUpdateToTargets(a0 context.Context, a1 []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error) {
+	if f.BatchUpdateToTargetsFunc == nil {
+		panic("streamtest: FlatFeed.BatchUpdateToTargets not implemented")
+	}
+	return f.BatchUpdateToTargetsFunc(a0, a1)
+}
+
+// FeedID calls FeedIDFunc.
+func (f *FlatFeed) FeedID() stream.FeedID {
+	if f.FeedIDFunc == nil {
+		panic("streamtest: FlatFeed.FeedID not implemented")
+	}
+	return f.FeedIDFunc()
+}
+
+// Follow calls FollowFunc.
+func (f *FlatFeed) Follow(a0 context.Context, a1 *stream.FlatFeed, a2 ...stream.FollowFeedOption) (*stream.BaseResponse, error) {
+	if f.FollowFunc == nil {
+		panic("streamtest: FlatFeed.Follow not implemented")
+	}
+	return f.FollowFunc(a0, a1, a2...)
+}
+
+// FollowStats calls FollowStatsFunc.
+func (f *FlatFeed) FollowStats(a0 context.Context, a1 ...stream.FollowStatOption) (*stream.FollowStatResponse, error) {
+	if f.FollowStatsFunc == nil {
+		panic("streamtest: FlatFeed.FollowStats not implemented")
+	}
+	return f.FollowStatsFunc(a0, a1...)
+}
+
+// GetActivities calls GetActivitiesFunc.
+func (f *FlatFeed) GetActivities(a0 context.Context, a1 ...stream.GetActivitiesOption) (*stream.FlatFeedResponse, error) {
+	if f.GetActivitiesFunc == nil {
+		panic("streamtest: FlatFeed.GetActivities not implemented")
+	}
+	return f.GetActivitiesFunc(a0, a1...)
+}
+
+// GetActivitiesWithRanking calls GetActivitiesWithRankingFunc.
+func (f *FlatFeed) GetActivitiesWithRanking(a0 context.Context, a1 string, a2 ...stream.GetActivitiesOption) (*stream.FlatFeedResponse, error) {
+	if f.GetActivitiesWithRankingFunc == nil {
+		panic("streamtest: FlatFeed.GetActivitiesWithRanking not implemented")
+	}
+	return f.GetActivitiesWithRankingFunc(a0, a1, a2...)
+}
+
+// GetEnrichedActivities calls GetEnrichedActivitiesFunc.
+func (f *FlatFeed) GetEnrichedActivities(a0 context.Context, a1 ...stream.GetActivitiesOption) (*stream.EnrichedFlatFeedResponse, error) {
+	if f.GetEnrichedActivitiesFunc == nil {
+		panic("streamtest: FlatFeed.GetEnrichedActivities not implemented")
+	}
+	return f.GetEnrichedActivitiesFunc(a0, a1...)
+}
+
+// GetEnrichedActivitiesWithRanking calls GetEnrichedActivitiesWithRankingFunc.
+func (f *FlatFeed) GetEnrichedActivitiesWithRanking(a0 context.Context, a1 string, a2 ...stream.GetActivitiesOption) (*stream.EnrichedFlatFeedResponse, error) {
+	if f.GetEnrichedActivitiesWithRankingFunc == nil {
+		panic("streamtest: FlatFeed.GetEnrichedActivitiesWithRanking not implemented")
+	}
+	return f.GetEnrichedActivitiesWithRankingFunc(a0, a1, a2...)
+}
+
+// GetFollowers calls GetFollowersFunc.
+func (f *FlatFeed) GetFollowers(a0 context.Context, a1 ...stream.FollowersOption) (*stream.FollowersResponse, error) {
+	if f.GetFollowersFunc == nil {
+		panic("streamtest: FlatFeed.GetFollowers not implemented")
+	}
+	return f.GetFollowersFunc(a0, a1...)
+}
+
+// GetFollowing calls GetFollowingFunc.
+func (f *FlatFeed) GetFollowing(a0 context.Context, a1 ...stream.FollowingOption) (*stream.FollowingResponse, error) {
+	if f.GetFollowingFunc == nil {
+		panic("streamtest: FlatFeed.GetFollowing not implemented")
+	}
+	return f.GetFollowingFunc(a0, a1...)
+}
+
+// GetNextPageActivities calls GetNextPageActivitiesFunc.
+func (f *FlatFeed) GetNextPageActivities(a0 context.Context, a1 *stream.FlatFeedResponse) (*stream.FlatFeedResponse, error) {
+	if f.GetNextPageActivitiesFunc == nil {
+		panic("streamtest: FlatFeed.GetNextPageActivities not implemented")
+	}
+	return f.GetNextPageActivitiesFunc(a0, a1)
+}
+
+// GetNextPageEnrichedActivities calls GetNextPageEnrichedActivitiesFunc.
+func (f *FlatFeed) GetNextPageEnrichedActivities(a0 context.Context, a1 *stream.EnrichedFlatFeedResponse) (*stream.EnrichedFlatFeedResponse, error) {
+	if f.GetNextPageEnrichedActivitiesFunc == nil {
+		panic("streamtest: FlatFeed.GetNextPageEnrichedActivities not implemented")
+	}
+	return f.GetNextPageEnrichedActivitiesFunc(a0, a1)
+}
+
+// ID calls IDFunc.
+func (f *FlatFeed) ID() string {
+	if f.IDFunc == nil {
+		panic("streamtest: FlatFeed.ID not implemented")
+	}
+	return f.IDFunc()
+}
+
+// RealtimeToken calls RealtimeTokenFunc.
+func (f *FlatFeed) RealtimeToken(a0 bool) string {
+	if f.RealtimeTokenFunc == nil {
+		panic("streamtest: FlatFeed.RealtimeToken not implemented")
+	}
+	return f.RealtimeTokenFunc(a0)
+}
+
+// RemoveActivityByForeignID calls RemoveActivityByForeignIDFunc.
+func (f *FlatFeed) RemoveActivityByForeignID(a0 context.Context, a1 string) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByForeignIDFunc == nil {
+		panic("streamtest: FlatFeed.RemoveActivityByForeignID not implemented")
+	}
+	return f.RemoveActivityByForeignIDFunc(a0, a1)
+}
+
+// RemoveActivityByID calls RemoveActivityByIDFunc.
+func (f *FlatFeed) RemoveActivityByID(a0 context.Context, a1 string, a2 ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByIDFunc == nil {
+		panic("streamtest: FlatFeed.RemoveActivityByID not implemented")
+	}
+	return f.RemoveActivityByIDFunc(a0, a1, a2...)
+}
+
+// Slug calls SlugFunc.
+func (f *FlatFeed) Slug() string {
+	if f.SlugFunc == nil {
+		panic("streamtest: FlatFeed.Slug not implemented")
+	}
+	return f.SlugFunc()
+}
+
+// Unfollow calls UnfollowFunc.
+func (f *FlatFeed) Unfollow(a0 context.Context, a1 stream.Feed, a2 ...stream.UnfollowOption) (*stream.BaseResponse, error) {
+	if f.UnfollowFunc == nil {
+		panic("streamtest: FlatFeed.Unfollow not implemented")
+	}
+	return f.UnfollowFunc(a0, a1, a2...)
+}
+
+// UpdateToTargets calls UpdateToTargetsFunc.
+func (f *FlatFeed) UpdateToTargets(a0 context.Context, a1 stream.Activity, a2 ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error) {
+	if f.UpdateToTargetsFunc == nil {
+		panic("streamtest: FlatFeed.UpdateToTargets not implemented")
+	}
+	return f.UpdateToTargetsFunc(a0, a1, a2...)
+}
+
+// UserID calls UserIDFunc.
+func (f *FlatFeed) UserID() string {
+	if f.UserIDFunc == nil {
+		panic("streamtest: FlatFeed.UserID not implemented")
+	}
+	return f.UserIDFunc()
+}
+
+// AggregatedFeed is a fake stream.AggregatedFeedInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type AggregatedFeed struct {
+	AddActivitiesFunc                    func(context.Context, ...stream.Activity) (*stream.AddActivitiesResponse, error)
+	AddActivityFunc                      func(context.Context, stream.Activity) (*stream.AddActivityResponse, error)
+	BatchUpdateToTargetsFunc             func(context.Context, []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error)
+	FeedIDFunc                           func() stream.FeedID
+	FollowFunc                           func(context.Context, *stream.FlatFeed, ...stream.FollowFeedOption) (*stream.BaseResponse, error)
+	GetActivitiesFunc                    func(context.Context, ...stream.GetActivitiesOption) (*stream.AggregatedFeedResponse, error)
+	GetActivitiesWithRankingFunc         func(context.Context, string, ...stream.GetActivitiesOption) (*stream.AggregatedFeedResponse, error)
+	GetEnrichedActivitiesFunc            func(context.Context, ...stream.GetActivitiesOption) (*stream.EnrichedAggregatedFeedResponse, error)
+	GetEnrichedActivitiesWithRankingFunc func(context.Context, string, ...stream.GetActivitiesOption) (*stream.EnrichedAggregatedFeedResponse, error)
+	GetFollowingFunc                     func(context.Context, ...stream.FollowingOption) (*stream.FollowingResponse, error)
+	GetNextPageActivitiesFunc            func(context.Context, *stream.AggregatedFeedResponse) (*stream.AggregatedFeedResponse, error)
+	GetNextPageEnrichedActivitiesFunc    func(context.Context, *stream.EnrichedAggregatedFeedResponse) (*stream.EnrichedAggregatedFeedResponse, error)
+	IDFunc                               func() string
+	RealtimeTokenFunc                    func(bool) string
+	RemoveActivityByForeignIDFunc        func(context.Context, string) (*stream.RemoveActivityResponse, error)
+	RemoveActivityByIDFunc               func(context.Context, string, ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error)
+	SlugFunc                             func() string
+	UnfollowFunc                         func(context.Context, stream.Feed, ...stream.UnfollowOption) (*stream.BaseResponse, error)
+	UpdateToTargetsFunc                  func(context.Context, stream.Activity, ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error)
+	UserIDFunc                           func() string
+}
+
+// AddActivities calls AddActivitiesFunc.
+func (f *AggregatedFeed) AddActivities(a0 context.Context, a1 ...stream.Activity) (*stream.AddActivitiesResponse, error) {
+	if f.AddActivitiesFunc == nil {
+		panic("streamtest: AggregatedFeed.AddActivities not implemented")
+	}
+	return f.AddActivitiesFunc(a0, a1...)
+}
+
+// AddActivity calls AddActivityFunc.
+func (f *AggregatedFeed) AddActivity(a0 context.Context, a1 stream.Activity) (*stream.AddActivityResponse, error) {
+	if f.AddActivityFunc == nil {
+		panic("streamtest: AggregatedFeed.AddActivity not implemented")
+	}
+	return f.AddActivityFunc(a0, a1)
+}
+
+// BatchUpdateToTargets calls BatchUpdateToTargetsFunc.
+func (f *AggregatedFeed) BatchUpdateToTargets(a0 context.Context, a1 []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error) {
+	if f.BatchUpdateToTargetsFunc == nil {
+		panic("streamtest: AggregatedFeed.BatchUpdateToTargets not implemented")
+	}
+	return f.BatchUpdateToTargetsFunc(a0, a1)
+}
+
+// FeedID calls FeedIDFunc.
+func (f *AggregatedFeed) FeedID() stream.FeedID {
+	if f.FeedIDFunc == nil {
+		panic("streamtest: AggregatedFeed.FeedID not implemented")
+	}
+	return f.FeedIDFunc()
+}
+
+// Follow calls FollowFunc.
+func (f *AggregatedFeed) Follow(a0 context.Context, a1 *stream.FlatFeed, a2 ...stream.FollowFeedOption) (*stream.BaseResponse, error) {
+	if f.FollowFunc == nil {
+		panic("streamtest: AggregatedFeed.Follow not implemented")
+	}
+	return f.FollowFunc(a0, a1, a2...)
+}
+
+// GetActivities calls GetActivitiesFunc.
+func (f *AggregatedFeed) GetActivities(a0 context.Context, a1 ...stream.GetActivitiesOption) (*stream.AggregatedFeedResponse, error) {
+	if f.GetActivitiesFunc == nil {
+		panic("streamtest: AggregatedFeed.GetActivities not implemented")
+	}
+	return f.GetActivitiesFunc(a0, a1...)
+}
+
+// GetActivitiesWithRanking calls GetActivitiesWithRankingFunc.
+func (f *AggregatedFeed) GetActivitiesWithRanking(a0 context.Context, a1 string, a2 ...stream.GetActivitiesOption) (*stream.AggregatedFeedResponse, error) {
+	if f.GetActivitiesWithRankingFunc == nil {
+		panic("streamtest: AggregatedFeed.GetActivitiesWithRanking not implemented")
+	}
+	return f.GetActivitiesWithRankingFunc(a0, a1, a2...)
+}
+
+// GetEnrichedActivities calls GetEnrichedActivitiesFunc.
+func (f *AggregatedFeed) GetEnrichedActivities(a0 context.Context, a1 ...stream.GetActivitiesOption) (*stream.EnrichedAggregatedFeedResponse, error) {
+	if f.GetEnrichedActivitiesFunc == nil {
+		panic("streamtest: AggregatedFeed.GetEnrichedActivities not implemented")
+	}
+	return f.GetEnrichedActivitiesFunc(a0, a1...)
+}
+
+// GetEnrichedActivitiesWithRanking calls GetEnrichedActivitiesWithRankingFunc.
+func (f *AggregatedFeed) GetEnrichedActivitiesWithRanking(a0 context.Context, a1 string, a2 ...stream.GetActivitiesOption) (*stream.EnrichedAggregatedFeedResponse, error) {
+	if f.GetEnrichedActivitiesWithRankingFunc == nil {
+		panic("streamtest: AggregatedFeed.GetEnrichedActivitiesWithRanking not implemented")
+	}
+	return f.GetEnrichedActivitiesWithRankingFunc(a0, a1, a2...)
+}
+
+// GetFollowing calls GetFollowingFunc.
+func (f *AggregatedFeed) GetFollowing(a0 context.Context, a1 ...stream.FollowingOption) (*stream.FollowingResponse, error) {
+	if f.GetFollowingFunc == nil {
+		panic("streamtest: AggregatedFeed.GetFollowing not implemented")
+	}
+	return f.GetFollowingFunc(a0, a1...)
+}
+
+// GetNextPageActivities calls GetNextPageActivitiesFunc.
+func (f *AggregatedFeed) GetNextPageActivities(a0 context.Context, a1 *stream.AggregatedFeedResponse) (*stream.AggregatedFeedResponse, error) {
+	if f.GetNextPageActivitiesFunc == nil {
+		panic("streamtest: AggregatedFeed.GetNextPageActivities not implemented")
+	}
+	return f.GetNextPageActivitiesFunc(a0, a1)
+}
+
+// GetNextPageEnrichedActivities calls GetNextPageEnrichedActivitiesFunc.
+func (f *AggregatedFeed) GetNextPageEnrichedActivities(a0 context.Context, a1 *stream.EnrichedAggregatedFeedResponse) (*stream.EnrichedAggregatedFeedResponse, error) {
+	if f.GetNextPageEnrichedActivitiesFunc == nil {
+		panic("streamtest: AggregatedFeed.GetNextPageEnrichedActivities not implemented")
+	}
+	return f.GetNextPageEnrichedActivitiesFunc(a0, a1)
+}
+
+// ID calls IDFunc.
+func (f *AggregatedFeed) ID() string {
+	if f.IDFunc == nil {
+		panic("streamtest: AggregatedFeed.ID not implemented")
+	}
+	return f.IDFunc()
+}
+
+// RealtimeToken calls RealtimeTokenFunc.
+func (f *AggregatedFeed) RealtimeToken(a0 bool) string {
+	if f.RealtimeTokenFunc == nil {
+		panic("streamtest: AggregatedFeed.RealtimeToken not implemented")
+	}
+	return f.RealtimeTokenFunc(a0)
+}
+
+// RemoveActivityByForeignID calls RemoveActivityByForeignIDFunc.
+func (f *AggregatedFeed) RemoveActivityByForeignID(a0 context.Context, a1 string) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByForeignIDFunc == nil {
+		panic("streamtest: AggregatedFeed.RemoveActivityByForeignID not implemented")
+	}
+	return f.RemoveActivityByForeignIDFunc(a0, a1)
+}
+
+// RemoveActivityByID calls RemoveActivityByIDFunc.
+func (f *AggregatedFeed) RemoveActivityByID(a0 context.Context, a1 string, a2 ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByIDFunc == nil {
+		panic("streamtest: AggregatedFeed.RemoveActivityByID not implemented")
+	}
+	return f.RemoveActivityByIDFunc(a0, a1, a2...)
+}
+
+// Slug calls SlugFunc.
+func (f *AggregatedFeed) Slug() string {
+	if f.SlugFunc == nil {
+		panic("streamtest: AggregatedFeed.Slug not implemented")
+	}
+	return f.SlugFunc()
+}
+
+// Unfollow calls UnfollowFunc.
+func (f *AggregatedFeed) Unfollow(a0 context.Context, a1 stream.Feed, a2 ...stream.UnfollowOption) (*stream.BaseResponse, error) {
+	if f.UnfollowFunc == nil {
+		panic("streamtest: AggregatedFeed.Unfollow not implemented")
+	}
+	return f.UnfollowFunc(a0, a1, a2...)
+}
+
+// UpdateToTargets calls UpdateToTargetsFunc.
+func (f *AggregatedFeed) UpdateToTargets(a0 context.Context, a1 stream.Activity, a2 ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error) {
+	if f.UpdateToTargetsFunc == nil {
+		panic("streamtest: AggregatedFeed.UpdateToTargets not implemented")
+	}
+	return f.UpdateToTargetsFunc(a0, a1, a2...)
+}
+
+// UserID calls UserIDFunc.
+func (f *AggregatedFeed) UserID() string {
+	if f.UserIDFunc == nil {
+		panic("streamtest: AggregatedFeed.UserID not implemented")
+	}
+	return f.UserIDFunc()
+}
+
+// NotificationFeed is a fake stream.NotificationFeedInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type NotificationFeed struct {
+	AddActivitiesFunc                 func(context.Context, ...stream.Activity) (*stream.AddActivitiesResponse, error)
+	AddActivityFunc                   func(context.Context, stream.Activity) (*stream.AddActivityResponse, error)
+	BatchUpdateToTargetsFunc          func(context.Context, []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error)
+	FeedIDFunc                        func() stream.FeedID
+	FollowFunc                        func(context.Context, *stream.FlatFeed, ...stream.FollowFeedOption) (*stream.BaseResponse, error)
+	GetActivitiesFunc                 func(context.Context, ...stream.GetActivitiesOption) (*stream.NotificationFeedResponse, error)
+	GetEnrichedActivitiesFunc         func(context.Context, ...stream.GetActivitiesOption) (*stream.EnrichedNotificationFeedResponse, error)
+	GetFollowingFunc                  func(context.Context, ...stream.FollowingOption) (*stream.FollowingResponse, error)
+	GetNextPageActivitiesFunc         func(context.Context, *stream.NotificationFeedResponse) (*stream.NotificationFeedResponse, error)
+	GetNextPageEnrichedActivitiesFunc func(context.Context, *stream.EnrichedNotificationFeedResponse) (*stream.EnrichedNotificationFeedResponse, error)
+	IDFunc                            func() string
+	RealtimeTokenFunc                 func(bool) string
+	RemoveActivityByForeignIDFunc     func(context.Context, string) (*stream.RemoveActivityResponse, error)
+	RemoveActivityByIDFunc            func(context.Context, string, ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error)
+	SlugFunc                          func() string
+	UnfollowFunc                      func(context.Context, stream.Feed, ...stream.UnfollowOption) (*stream.BaseResponse, error)
+	UpdateToTargetsFunc               func(context.Context, stream.Activity, ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error)
+	UserIDFunc                        func() string
+}
+
+// AddActivities calls AddActivitiesFunc.
+func (f *NotificationFeed) AddActivities(a0 context.Context, a1 ...stream.Activity) (*stream.AddActivitiesResponse, error) {
+	if f.AddActivitiesFunc == nil {
+		panic("streamtest: NotificationFeed.AddActivities not implemented")
+	}
+	return f.AddActivitiesFunc(a0, a1...)
+}
+
+// AddActivity calls AddActivityFunc.
+func (f *NotificationFeed) AddActivity(a0 context.Context, a1 stream.Activity) (*stream.AddActivityResponse, error) {
+	if f.AddActivityFunc == nil {
+		panic("streamtest: NotificationFeed.AddActivity not implemented")
+	}
+	return f.AddActivityFunc(a0, a1)
+}
+
+// BatchUpdateToTargets calls BatchUpdateToTargetsFunc.
+func (f *NotificationFeed) BatchUpdateToTargets(a0 context.Context, a1 []stream.UpdateToTargetsRequest) (*stream.UpdateToTargetsResponse, error) {
+	if f.BatchUpdateToTargetsFunc == nil {
+		panic("streamtest: NotificationFeed.BatchUpdateToTargets not implemented")
+	}
+	return f.BatchUpdateToTargetsFunc(a0, a1)
+}
+
+// FeedID calls FeedIDFunc.
+func (f *NotificationFeed) FeedID() stream.FeedID {
+	if f.FeedIDFunc == nil {
+		panic("streamtest: NotificationFeed.FeedID not implemented")
+	}
+	return f.FeedIDFunc()
+}
+
+// Follow calls FollowFunc.
+func (f *NotificationFeed) Follow(a0 context.Context, a1 *stream.FlatFeed, a2 ...stream.FollowFeedOption) (*stream.BaseResponse, error) {
+	if f.FollowFunc == nil {
+		panic("streamtest: NotificationFeed.Follow not implemented")
+	}
+	return f.FollowFunc(a0, a1, a2...)
+}
+
+// GetActivities calls GetActivitiesFunc.
+func (f *NotificationFeed) GetActivities(a0 context.Context, a1 ...stream.GetActivitiesOption) (*stream.NotificationFeedResponse, error) {
+	if f.GetActivitiesFunc == nil {
+		panic("streamtest: NotificationFeed.GetActivities not implemented")
+	}
+	return f.GetActivitiesFunc(a0, a1...)
+}
+
+// GetEnrichedActivities calls GetEnrichedActivitiesFunc.
+func (f *NotificationFeed) GetEnrichedActivities(a0 context.Context, a1 ...stream.GetActivitiesOption) (*stream.EnrichedNotificationFeedResponse, error) {
+	if f.GetEnrichedActivitiesFunc == nil {
+		panic("streamtest: NotificationFeed.GetEnrichedActivities not implemented")
+	}
+	return f.GetEnrichedActivitiesFunc(a0, a1...)
+}
+
+// GetFollowing calls GetFollowingFunc.
+func (f *NotificationFeed) GetFollowing(a0 context.Context, a1 ...stream.FollowingOption) (*stream.FollowingResponse, error) {
+	if f.GetFollowingFunc == nil {
+		panic("streamtest: NotificationFeed.GetFollowing not implemented")
+	}
+	return f.GetFollowingFunc(a0, a1...)
+}
+
+// GetNextPageActivities calls GetNextPageActivitiesFunc.
+func (f *NotificationFeed) GetNextPageActivities(a0 context.Context, a1 *stream.NotificationFeedResponse) (*stream.NotificationFeedResponse, error) {
+	if f.GetNextPageActivitiesFunc == nil {
+		panic("streamtest: NotificationFeed.GetNextPageActivities not implemented")
+	}
+	return f.GetNextPageActivitiesFunc(a0, a1)
+}
+
+// GetNextPageEnrichedActivities calls GetNextPageEnrichedActivitiesFunc.
+func (f *NotificationFeed) GetNextPageEnrichedActivities(a0 context.Context, a1 *stream.EnrichedNotificationFeedResponse) (*stream.EnrichedNotificationFeedResponse, error) {
+	if f.GetNextPageEnrichedActivitiesFunc == nil {
+		panic("streamtest: NotificationFeed.GetNextPageEnrichedActivities not implemented")
+	}
+	return f.GetNextPageEnrichedActivitiesFunc(a0, a1)
+}
+
+// ID calls IDFunc.
+func (f *NotificationFeed) ID() string {
+	if f.IDFunc == nil {
+		panic("streamtest: NotificationFeed.ID not implemented")
+	}
+	return f.IDFunc()
+}
+
+// RealtimeToken calls RealtimeTokenFunc.
+func (f *NotificationFeed) RealtimeToken(a0 bool) string {
+	if f.RealtimeTokenFunc == nil {
+		panic("streamtest: NotificationFeed.RealtimeToken not implemented")
+	}
+	return f.RealtimeTokenFunc(a0)
+}
+
+// RemoveActivityByForeignID calls RemoveActivityByForeignIDFunc.
+func (f *NotificationFeed) RemoveActivityByForeignID(a0 context.Context, a1 string) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByForeignIDFunc == nil {
+		panic("streamtest: NotificationFeed.RemoveActivityByForeignID not implemented")
+	}
+	return f.RemoveActivityByForeignIDFunc(a0, a1)
+}
+
+// RemoveActivityByID calls RemoveActivityByIDFunc.
+func (f *NotificationFeed) RemoveActivityByID(a0 context.Context, a1 string, a2 ...stream.RemoveActivityOption) (*stream.RemoveActivityResponse, error) {
+	if f.RemoveActivityByIDFunc == nil {
+		panic("streamtest: NotificationFeed.RemoveActivityByID not implemented")
+	}
+	return f.RemoveActivityByIDFunc(a0, a1, a2...)
+}
+
+// Slug calls SlugFunc.
+func (f *NotificationFeed) Slug() string {
+	if f.SlugFunc == nil {
+		panic("streamtest: NotificationFeed.Slug not implemented")
+	}
+	return f.SlugFunc()
+}
+
+// Unfollow calls UnfollowFunc.
+func (f *NotificationFeed) Unfollow(a0 context.Context, a1 stream.Feed, a2 ...stream.UnfollowOption) (*stream.BaseResponse, error) {
+	if f.UnfollowFunc == nil {
+		panic("streamtest: NotificationFeed.Unfollow not implemented")
+	}
+	return f.UnfollowFunc(a0, a1, a2...)
+}
+
+// UpdateToTargets calls UpdateToTargetsFunc.
+func (f *NotificationFeed) UpdateToTargets(a0 context.Context, a1 stream.Activity, a2 ...stream.UpdateToTargetsOption) (*stream.UpdateToTargetsResponse, error) {
+	if f.UpdateToTargetsFunc == nil {
+		panic("streamtest: NotificationFeed.UpdateToTargets not implemented")
+	}
+	return f.UpdateToTargetsFunc(a0, a1, a2...)
+}
+
+// UserID calls UserIDFunc.
+func (f *NotificationFeed) UserID() string {
+	if f.UserIDFunc == nil {
+		panic("streamtest: NotificationFeed.UserID not implemented")
+	}
+	return f.UserIDFunc()
+}
+
+// ReactionsClient is a fake stream.ReactionsClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type ReactionsClient struct {
+	AddFunc                          func(context.Context, stream.AddReactionRequestObject) (*stream.ReactionResponse, error)
+	AddChildFunc                     func(context.Context, string, stream.AddReactionRequestObject) (*stream.ReactionResponse, error)
+	AddManyFunc                      func(context.Context, []stream.AddReactionRequestObject, ...stream.BatchOption) (*stream.BatchResult, error)
+	DeleteFunc                       func(context.Context, string, ...stream.ReactionOption) (*stream.ReactionResponse, error)
+	DeleteManyFunc                   func(context.Context, []string, ...stream.BatchOption) (*stream.BatchResult, error)
+	FilterFunc                       func(context.Context, stream.FilterReactionsAttribute, ...stream.FilterReactionsOption) (*stream.FilterReactionResponse, error)
+	GetFunc                          func(context.Context, string) (*stream.ReactionResponse, error)
+	GetNextPageFilteredReactionsFunc func(context.Context, *stream.FilterReactionResponse) (*stream.FilterReactionResponse, error)
+	GetThreadFunc                    func(context.Context, string, int, ...stream.ThreadOption) (*stream.ReactionThread, error)
+	RestoreFunc                      func(context.Context, string, ...stream.ReactionOption) error
+	RestoreManyFunc                  func(context.Context, []string, ...stream.BatchOption) (*stream.BatchResult, error)
+	SoftDeleteFunc                   func(context.Context, string, ...stream.ReactionOption) error
+	SoftDeleteManyFunc               func(context.Context, []string, ...stream.BatchOption) (*stream.BatchResult, error)
+	UpdateFunc                       func(context.Context, string, map[string]any, []string) (*stream.ReactionResponse, error)
+	UpdateManyFunc                   func(context.Context, []stream.ReactionUpdate, ...stream.BatchOption) (*stream.BatchResult, error)
+	UpdateWithFeedIDsFunc            func(context.Context, string, map[string]any, []stream.FeedID) (*stream.ReactionResponse, error)
+}
+
+// Add calls AddFunc.
+func (f *ReactionsClient) Add(a0 context.Context, a1 stream.AddReactionRequestObject) (*stream.ReactionResponse, error) {
+	if f.AddFunc == nil {
+		panic("streamtest: ReactionsClient.Add not implemented")
+	}
+	return f.AddFunc(a0, a1)
+}
+
+// AddChild calls AddChildFunc.
+func (f *ReactionsClient) AddChild(a0 context.Context, a1 string, a2 stream.AddReactionRequestObject) (*stream.ReactionResponse, error) {
+	if f.AddChildFunc == nil {
+		panic("streamtest: ReactionsClient.AddChild not implemented")
+	}
+	return f.AddChildFunc(a0, a1, a2)
+}
+
+// AddMany calls AddManyFunc.
+func (f *ReactionsClient) AddMany(a0 context.Context, a1 []stream.AddReactionRequestObject, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.AddManyFunc == nil {
+		panic("streamtest: ReactionsClient.AddMany not implemented")
+	}
+	return f.AddManyFunc(a0, a1, a2...)
+}
+
+// Delete calls DeleteFunc.
+func (f *ReactionsClient) Delete(a0 context.Context, a1 string, a2 ...stream.ReactionOption) (*stream.ReactionResponse, error) {
+	if f.DeleteFunc == nil {
+		panic("streamtest: ReactionsClient.Delete not implemented")
+	}
+	return f.DeleteFunc(a0, a1, a2...)
+}
+
+// DeleteMany calls DeleteManyFunc.
+func (f *ReactionsClient) DeleteMany(a0 context.Context, a1 []string, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.DeleteManyFunc == nil {
+		panic("streamtest: ReactionsClient.DeleteMany not implemented")
+	}
+	return f.DeleteManyFunc(a0, a1, a2...)
+}
+
+// Filter calls FilterFunc.
+func (f *ReactionsClient) Filter(a0 context.Context, a1 stream.FilterReactionsAttribute, a2 ...stream.FilterReactionsOption) (*stream.FilterReactionResponse, error) {
+	if f.FilterFunc == nil {
+		panic("streamtest: ReactionsClient.Filter not implemented")
+	}
+	return f.FilterFunc(a0, a1, a2...)
+}
+
+// Get calls GetFunc.
+func (f *ReactionsClient) Get(a0 context.Context, a1 string) (*stream.ReactionResponse, error) {
+	if f.GetFunc == nil {
+		panic("streamtest: ReactionsClient.Get not implemented")
+	}
+	return f.GetFunc(a0, a1)
+}
+
+// GetNextPageFilteredReactions calls GetNextPageFilteredReactionsFunc.
+func (f *ReactionsClient) GetNextPageFilteredReactions(a0 context.Context, a1 *stream.FilterReactionResponse) (*stream.FilterReactionResponse, error) {
+	if f.GetNextPageFilteredReactionsFunc == nil {
+		panic("streamtest: ReactionsClient.GetNextPageFilteredReactions not implemented")
+	}
+	return f.GetNextPageFilteredReactionsFunc(a0, a1)
+}
+
+// GetThread calls GetThreadFunc.
+func (f *ReactionsClient) GetThread(a0 context.Context, a1 string, a2 int, a3 ...stream.ThreadOption) (*stream.ReactionThread, error) {
+	if f.GetThreadFunc == nil {
+		panic("streamtest: ReactionsClient.GetThread not implemented")
+	}
+	return f.GetThreadFunc(a0, a1, a2, a3...)
+}
+
+// Restore calls RestoreFunc.
+func (f *ReactionsClient) Restore(a0 context.Context, a1 string, a2 ...stream.ReactionOption) error {
+	if f.RestoreFunc == nil {
+		panic("streamtest: ReactionsClient.Restore not implemented")
+	}
+	return f.RestoreFunc(a0, a1, a2...)
+}
+
+// RestoreMany calls RestoreManyFunc.
+func (f *ReactionsClient) RestoreMany(a0 context.Context, a1 []string, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.RestoreManyFunc == nil {
+		panic("streamtest: ReactionsClient.RestoreMany not implemented")
+	}
+	return f.RestoreManyFunc(a0, a1, a2...)
+}
+
+// SoftDelete calls SoftDeleteFunc.
+func (f *ReactionsClient) SoftDelete(a0 context.Context, a1 string, a2 ...stream.ReactionOption) error {
+	if f.SoftDeleteFunc == nil {
+		panic("streamtest: ReactionsClient.SoftDelete not implemented")
+	}
+	return f.SoftDeleteFunc(a0, a1, a2...)
+}
+
+// SoftDeleteMany calls SoftDeleteManyFunc.
+func (f *ReactionsClient) SoftDeleteMany(a0 context.Context, a1 []string, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.SoftDeleteManyFunc == nil {
+		panic("streamtest: ReactionsClient.SoftDeleteMany not implemented")
+	}
+	return f.SoftDeleteManyFunc(a0, a1, a2...)
+}
+
+// Update calls UpdateFunc.
+func (f *ReactionsClient) Update(a0 context.Context, a1 string, a2 map[string]any, a3 []string) (*stream.ReactionResponse, error) {
+	if f.UpdateFunc == nil {
+		panic("streamtest: ReactionsClient.Update not implemented")
+	}
+	return f.UpdateFunc(a0, a1, a2, a3)
+}
+
+// UpdateMany calls UpdateManyFunc.
+func (f *ReactionsClient) UpdateMany(a0 context.Context, a1 []stream.ReactionUpdate, a2 ...stream.BatchOption) (*stream.BatchResult, error) {
+	if f.UpdateManyFunc == nil {
+		panic("streamtest: ReactionsClient.UpdateMany not implemented")
+	}
+	return f.UpdateManyFunc(a0, a1, a2...)
+}
+
+// UpdateWithFeedIDs calls UpdateWithFeedIDsFunc.
+func (f *ReactionsClient) UpdateWithFeedIDs(a0 context.Context, a1 string, a2 map[string]any, a3 []stream.FeedID) (*stream.ReactionResponse, error) {
+	if f.UpdateWithFeedIDsFunc == nil {
+		panic("streamtest: ReactionsClient.UpdateWithFeedIDs not implemented")
+	}
+	return f.UpdateWithFeedIDsFunc(a0, a1, a2, a3)
+}
+
+// CollectionsClient is a fake stream.CollectionsClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type CollectionsClient struct {
+	AddFunc             func(context.Context, string, stream.CollectionObject, ...stream.AddObjectOption) (*stream.CollectionObjectResponse, error)
+	CreateReferenceFunc func(string, string) string
+	DeleteFunc          func(context.Context, string, string) (*stream.BaseResponse, error)
+	DeleteManyFunc      func(context.Context, string, ...string) (*stream.BaseResponse, error)
+	GetFunc             func(context.Context, string, string) (*stream.CollectionObjectResponse, error)
+	SelectFunc          func(context.Context, string, ...string) (*stream.GetCollectionResponse, error)
+	UpdateFunc          func(context.Context, string, string, map[string]any) (*stream.CollectionObjectResponse, error)
+	UpsertFunc          func(context.Context, string, ...stream.CollectionObject) (*stream.BaseResponse, error)
+}
+
+// Add calls AddFunc.
+func (f *CollectionsClient) Add(a0 context.Context, a1 string, a2 stream.CollectionObject, a3 ...stream.AddObjectOption) (*stream.CollectionObjectResponse, error) {
+	if f.AddFunc == nil {
+		panic("streamtest: CollectionsClient.Add not implemented")
+	}
+	return f.AddFunc(a0, a1, a2, a3...)
+}
+
+// CreateReference calls CreateReferenceFunc.
+func (f *CollectionsClient) CreateReference(a0 string, a1 string) string {
+	if f.CreateReferenceFunc == nil {
+		panic("streamtest: CollectionsClient.CreateReference not implemented")
+	}
+	return f.CreateReferenceFunc(a0, a1)
+}
+
+// Delete calls DeleteFunc.
+func (f *CollectionsClient) Delete(a0 context.Context, a1 string, a2 string) (*stream.BaseResponse, error) {
+	if f.DeleteFunc == nil {
+		panic("streamtest: CollectionsClient.Delete not implemented")
+	}
+	return f.DeleteFunc(a0, a1, a2)
+}
+
+// DeleteMany calls DeleteManyFunc.
+func (f *CollectionsClient) DeleteMany(a0 context.Context, a1 string, a2 ...string) (*stream.BaseResponse, error) {
+	if f.DeleteManyFunc == nil {
+		panic("streamtest: CollectionsClient.DeleteMany not implemented")
+	}
+	return f.DeleteManyFunc(a0, a1, a2...)
+}
+
+// Get calls GetFunc.
+func (f *CollectionsClient) Get(a0 context.Context, a1 string, a2 string) (*stream.CollectionObjectResponse, error) {
+	if f.GetFunc == nil {
+		panic("streamtest: CollectionsClient.Get not implemented")
+	}
+	return f.GetFunc(a0, a1, a2)
+}
+
+// Select calls SelectFunc.
+func (f *CollectionsClient) Select(a0 context.Context, a1 string, a2 ...string) (*stream.GetCollectionResponse, error) {
+	if f.SelectFunc == nil {
+		panic("streamtest: CollectionsClient.Select not implemented")
+	}
+	return f.SelectFunc(a0, a1, a2...)
+}
+
+// Update calls UpdateFunc.
+func (f *CollectionsClient) Update(a0 context.Context, a1 string, a2 string, a3 map[string]any) (*stream.CollectionObjectResponse, error) {
+	if f.UpdateFunc == nil {
+		panic("streamtest: CollectionsClient.Update not implemented")
+	}
+	return f.UpdateFunc(a0, a1, a2, a3)
+}
+
+// Upsert calls UpsertFunc.
+func (f *CollectionsClient) Upsert(a0 context.Context, a1 string, a2 ...stream.CollectionObject) (*stream.BaseResponse, error) {
+	if f.UpsertFunc == nil {
+		panic("streamtest: CollectionsClient.Upsert not implemented")
+	}
+	return f.UpsertFunc(a0, a1, a2...)
+}
+
+// UsersClient is a fake stream.UsersClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type UsersClient struct {
+	AddFunc             func(context.Context, stream.User, bool) (*stream.UserResponse, error)
+	CreateReferenceFunc func(string) string
+	DeleteFunc          func(context.Context, string) (*stream.BaseResponse, error)
+	GetFunc             func(context.Context, string) (*stream.UserResponse, error)
+	UpdateFunc          func(context.Context, string, map[string]any) (*stream.UserResponse, error)
+}
+
+// Add calls AddFunc.
+func (f *UsersClient) Add(a0 context.Context, a1 stream.User, a2 bool) (*stream.UserResponse, error) {
+	if f.AddFunc == nil {
+		panic("streamtest: UsersClient.Add not implemented")
+	}
+	return f.AddFunc(a0, a1, a2)
+}
+
+// CreateReference calls CreateReferenceFunc.
+func (f *UsersClient) CreateReference(a0 string) string {
+	if f.CreateReferenceFunc == nil {
+		panic("streamtest: UsersClient.CreateReference not implemented")
+	}
+	return f.CreateReferenceFunc(a0)
+}
+
+// Delete calls DeleteFunc.
+func (f *UsersClient) Delete(a0 context.Context, a1 string) (*stream.BaseResponse, error) {
+	if f.DeleteFunc == nil {
+		panic("streamtest: UsersClient.Delete not implemented")
+	}
+	return f.DeleteFunc(a0, a1)
+}
+
+// Get calls GetFunc.
+func (f *UsersClient) Get(a0 context.Context, a1 string) (*stream.UserResponse, error) {
+	if f.GetFunc == nil {
+		panic("streamtest: UsersClient.Get not implemented")
+	}
+	return f.GetFunc(a0, a1)
+}
+
+// Update calls UpdateFunc.
+func (f *UsersClient) Update(a0 context.Context, a1 string, a2 map[string]any) (*stream.UserResponse, error) {
+	if f.UpdateFunc == nil {
+		panic("streamtest: UsersClient.Update not implemented")
+	}
+	return f.UpdateFunc(a0, a1, a2)
+}
+
+// ModerationClient is a fake stream.ModerationClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type ModerationClient struct {
+	BanUserFunc                        func(context.Context, string, string, string, time.Duration) error
+	FlagActivityFunc                   func(context.Context, string, string, string, ...stream.FlagOption) error
+	FlagReactionFunc                   func(context.Context, string, string, string, ...stream.FlagOption) error
+	FlagUserFunc                       func(context.Context, string, string, string, ...stream.FlagOption) error
+	GetFlagFunc                        func(context.Context, string) (*stream.FlagResponse, error)
+	InvalidateUserCacheFunc            func(context.Context, string) error
+	QueryFlagsFunc                     func(context.Context, stream.QueryFlagsFilters, stream.QueryFlagsPager) (*stream.QueryFlagsResponse, error)
+	ResolveFlagsFunc                   func(context.Context, []stream.Flag) (*stream.ResolvedFlags, error)
+	UnbanUserFunc                      func(context.Context, string, string) error
+	UnflagActivityFunc                 func(context.Context, string, string) error
+	UnflagReactionFunc                 func(context.Context, string, string) error
+	UnflagUserFunc                     func(context.Context, string, string) error
+	UpdateActivityModerationStatusFunc func(context.Context, string, string, string, string, string) error
+	UpdateActivityStatusFunc           func(context.Context, string, stream.ModerationStatusUpdate) error
+	UpdateReactionModerationStatusFunc func(context.Context, string, string, string, string, string) error
+	UpdateReactionStatusFunc           func(context.Context, string, stream.ModerationStatusUpdate) error
+	UpdateStatusBatchFunc              func(context.Context, stream.UpdateStatusBatchRequest) (*stream.UpdateStatusBatchResponse, error)
+}
+
+// BanUser calls BanUserFunc.
+func (f *ModerationClient) BanUser(a0 context.Context, a1 string, a2 string, a3 string, a4 time.Duration) error {
+	if f.BanUserFunc == nil {
+		panic("streamtest: ModerationClient.BanUser not implemented")
+	}
+	return f.BanUserFunc(a0, a1, a2, a3, a4)
+}
+
+// FlagActivity calls FlagActivityFunc.
+func (f *ModerationClient) FlagActivity(a0 context.Context, a1 string, a2 string, a3 string, a4 ...stream.FlagOption) error {
+	if f.FlagActivityFunc == nil {
+		panic("streamtest: ModerationClient.FlagActivity not implemented")
+	}
+	return f.FlagActivityFunc(a0, a1, a2, a3, a4...)
+}
+
+// FlagReaction calls FlagReactionFunc.
+func (f *ModerationClient) FlagReaction(a0 context.Context, a1 string, a2 string, a3 string, a4 ...stream.FlagOption) error {
+	if f.FlagReactionFunc == nil {
+		panic("streamtest: ModerationClient.FlagReaction not implemented")
+	}
+	return f.FlagReactionFunc(a0, a1, a2, a3, a4...)
+}
+
+// FlagUser calls FlagUserFunc.
+func (f *ModerationClient) FlagUser(a0 context.Context, a1 string, a2 string, a3 string, a4 ...stream.FlagOption) error {
+	if f.FlagUserFunc == nil {
+		panic("streamtest: ModerationClient.FlagUser not implemented")
+	}
+	return f.FlagUserFunc(a0, a1, a2, a3, a4...)
+}
+
+// GetFlag calls GetFlagFunc.
+func (f *ModerationClient) GetFlag(a0 context.Context, a1 string) (*stream.FlagResponse, error) {
+	if f.GetFlagFunc == nil {
+		panic("streamtest: ModerationClient.GetFlag not implemented")
+	}
+	return f.GetFlagFunc(a0, a1)
+}
+
+// InvalidateUserCache calls InvalidateUserCacheFunc.
+func (f *ModerationClient) InvalidateUserCache(a0 context.Context, a1 string) error {
+	if f.InvalidateUserCacheFunc == nil {
+		panic("streamtest: ModerationClient.InvalidateUserCache not implemented")
+	}
+	return f.InvalidateUserCacheFunc(a0, a1)
+}
+
+// QueryFlags calls QueryFlagsFunc.
+func (f *ModerationClient) QueryFlags(a0 context.Context, a1 stream.QueryFlagsFilters, a2 stream.QueryFlagsPager) (*stream.QueryFlagsResponse, error) {
+	if f.QueryFlagsFunc == nil {
+		panic("streamtest: ModerationClient.QueryFlags not implemented")
+	}
+	return f.QueryFlagsFunc(a0, a1, a2)
+}
+
+// ResolveFlags calls ResolveFlagsFunc.
+func (f *ModerationClient) ResolveFlags(a0 context.Context, a1 []stream.Flag) (*stream.ResolvedFlags, error) {
+	if f.ResolveFlagsFunc == nil {
+		panic("streamtest: ModerationClient.ResolveFlags not implemented")
+	}
+	return f.ResolveFlagsFunc(a0, a1)
+}
+
+// UnbanUser calls UnbanUserFunc.
+func (f *ModerationClient) UnbanUser(a0 context.Context, a1 string, a2 string) error {
+	if f.UnbanUserFunc == nil {
+		panic("streamtest: ModerationClient.UnbanUser not implemented")
+	}
+	return f.UnbanUserFunc(a0, a1, a2)
+}
+
+// UnflagActivity calls UnflagActivityFunc.
+func (f *ModerationClient) UnflagActivity(a0 context.Context, a1 string, a2 string) error {
+	if f.UnflagActivityFunc == nil {
+		panic("streamtest: ModerationClient.UnflagActivity not implemented")
+	}
+	return f.UnflagActivityFunc(a0, a1, a2)
+}
+
+// UnflagReaction calls UnflagReactionFunc.
+func (f *ModerationClient) UnflagReaction(a0 context.Context, a1 string, a2 string) error {
+	if f.UnflagReactionFunc == nil {
+		panic("streamtest: ModerationClient.UnflagReaction not implemented")
+	}
+	return f.UnflagReactionFunc(a0, a1, a2)
+}
+
+// UnflagUser calls UnflagUserFunc.
+func (f *ModerationClient) UnflagUser(a0 context.Context, a1 string, a2 string) error {
+	if f.UnflagUserFunc == nil {
+		panic("streamtest: ModerationClient.UnflagUser not implemented")
+	}
+	return f.UnflagUserFunc(a0, a1, a2)
+}
+
+// UpdateActivityModerationStatus calls UpdateActivityModerationStatusFunc.
+func (f *ModerationClient) UpdateActivityModerationStatus(a0 context.Context, a1 string, a2 string, a3 string, a4 string, a5 string) error {
+	if f.UpdateActivityModerationStatusFunc == nil {
+		panic("streamtest: ModerationClient.UpdateActivityModerationStatus not implemented")
+	}
+	return f.UpdateActivityModerationStatusFunc(a0, a1, a2, a3, a4, a5)
+}
+
+// UpdateActivityStatus calls UpdateActivityStatusFunc.
+func (f *ModerationClient) UpdateActivityStatus(a0 context.Context, a1 string, a2 stream.ModerationStatusUpdate) error {
+	if f.UpdateActivityStatusFunc == nil {
+		panic("streamtest: ModerationClient.UpdateActivityStatus not implemented")
+	}
+	return f.UpdateActivityStatusFunc(a0, a1, a2)
+}
+
+// UpdateReactionModerationStatus calls UpdateReactionModerationStatusFunc.
+func (f *ModerationClient) UpdateReactionModerationStatus(a0 context.Context, a1 string, a2 string, a3 string, a4 string, a5 string) error {
+	if f.UpdateReactionModerationStatusFunc == nil {
+		panic("streamtest: ModerationClient.UpdateReactionModerationStatus not implemented")
+	}
+	return f.UpdateReactionModerationStatusFunc(a0, a1, a2, a3, a4, a5)
+}
+
+// UpdateReactionStatus calls UpdateReactionStatusFunc.
+func (f *ModerationClient) UpdateReactionStatus(a0 context.Context, a1 string, a2 stream.ModerationStatusUpdate) error {
+	if f.UpdateReactionStatusFunc == nil {
+		panic("streamtest: ModerationClient.UpdateReactionStatus not implemented")
+	}
+	return f.UpdateReactionStatusFunc(a0, a1, a2)
+}
+
+// UpdateStatusBatch calls UpdateStatusBatchFunc.
+func (f *ModerationClient) UpdateStatusBatch(a0 context.Context, a1 stream.UpdateStatusBatchRequest) (*stream.UpdateStatusBatchResponse, error) {
+	if f.UpdateStatusBatchFunc == nil {
+		panic("streamtest: ModerationClient.UpdateStatusBatch not implemented")
+	}
+	return f.UpdateStatusBatchFunc(a0, a1)
+}
+
+// AnalyticsClient is a fake stream.AnalyticsClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type AnalyticsClient struct {
+	RedirectAndTrackFunc func(string, ...map[string]any) (string, error)
+	TrackEngagementFunc  func(context.Context, ...stream.EngagementEvent) (*stream.BaseResponse, error)
+	TrackImpressionFunc  func(context.Context, stream.ImpressionEventsData) (*stream.BaseResponse, error)
+}
+
+// RedirectAndTrack calls RedirectAndTrackFunc.
+func (f *AnalyticsClient) RedirectAndTrack(a0 string, a1 ...map[string]any) (string, error) {
+	if f.RedirectAndTrackFunc == nil {
+		panic("streamtest: AnalyticsClient.RedirectAndTrack not implemented")
+	}
+	return f.RedirectAndTrackFunc(a0, a1...)
+}
+
+// TrackEngagement calls TrackEngagementFunc.
+func (f *AnalyticsClient) TrackEngagement(a0 context.Context, a1 ...stream.EngagementEvent) (*stream.BaseResponse, error) {
+	if f.TrackEngagementFunc == nil {
+		panic("streamtest: AnalyticsClient.TrackEngagement not implemented")
+	}
+	return f.TrackEngagementFunc(a0, a1...)
+}
+
+// TrackImpression calls TrackImpressionFunc.
+func (f *AnalyticsClient) TrackImpression(a0 context.Context, a1 stream.ImpressionEventsData) (*stream.BaseResponse, error) {
+	if f.TrackImpressionFunc == nil {
+		panic("streamtest: AnalyticsClient.TrackImpression not implemented")
+	}
+	return f.TrackImpressionFunc(a0, a1)
+}
+
+// PersonalizationClient is a fake stream.PersonalizationClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type PersonalizationClient struct {
+	DeleteFunc func(context.Context, string, map[string]any) (*stream.PersonalizationResponse, error)
+	GetFunc    func(context.Context, string, map[string]any) (*stream.PersonalizationResponse, error)
+	PostFunc   func(context.Context, string, map[string]any, map[string]any) (*stream.PersonalizationResponse, error)
+}
+
+// Delete calls DeleteFunc.
+func (f *PersonalizationClient) Delete(a0 context.Context, a1 string, a2 map[string]any) (*stream.PersonalizationResponse, error) {
+	if f.DeleteFunc == nil {
+		panic("streamtest: PersonalizationClient.Delete not implemented")
+	}
+	return f.DeleteFunc(a0, a1, a2)
+}
+
+// Get calls GetFunc.
+func (f *PersonalizationClient) Get(a0 context.Context, a1 string, a2 map[string]any) (*stream.PersonalizationResponse, error) {
+	if f.GetFunc == nil {
+		panic("streamtest: PersonalizationClient.Get not implemented")
+	}
+	return f.GetFunc(a0, a1, a2)
+}
+
+// Post calls PostFunc.
+func (f *PersonalizationClient) Post(a0 context.Context, a1 string, a2 map[string]any, a3 map[string]any) (*stream.PersonalizationResponse, error) {
+	if f.PostFunc == nil {
+		panic("streamtest: PersonalizationClient.Post not implemented")
+	}
+	return f.PostFunc(a0, a1, a2, a3)
+}
+
+// AuditLogsClient is a fake stream.AuditLogsClientInterface. Each method calls the function
+// field having the same name and the Func suffix, and panics if it is nil.
+type AuditLogsClient struct {
+	ExportAuditLogsFunc func(context.Context, io.Writer, stream.QueryAuditLogsFilters, ...stream.ExportAuditLogsOption) (int, error)
+	QueryAuditLogsFunc  func(context.Context, stream.QueryAuditLogsFilters, stream.QueryAuditLogsPager) (*stream.QueryAuditLogsResponse, error)
+}
+
+// ExportAuditLogs calls ExportAuditLogsFunc.
+func (f *AuditLogsClient) ExportAuditLogs(a0 context.Context, a1 io.Writer, a2 stream.QueryAuditLogsFilters, a3 ...stream.ExportAuditLogsOption) (int, error) {
+	if f.ExportAuditLogsFunc == nil {
+		panic("streamtest: AuditLogsClient.ExportAuditLogs not implemented")
+	}
+	return f.ExportAuditLogsFunc(a0, a1, a2, a3...)
+}
+
+// QueryAuditLogs calls QueryAuditLogsFunc.
+func (f *AuditLogsClient) QueryAuditLogs(a0 context.Context, a1 stream.QueryAuditLogsFilters, a2 stream.QueryAuditLogsPager) (*stream.QueryAuditLogsResponse, error) {
+	if f.QueryAuditLogsFunc == nil {
+		panic("streamtest: AuditLogsClient.QueryAuditLogs not implemented")
+	}
+	return f.QueryAuditLogsFunc(a0, a1, a2)
+}
@@ -0,0 +1,47 @@
+package streamtest_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+	"github.com/GetStream/stream-go2/v8/streamtest"
+)
+
+func countActivities(ctx context.Context, client stream.ClientInterface, userID string) (int, error) {
+	feed, err := client.FlatFeed("user", userID)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := feed.GetActivities(ctx)
+	if err != nil {
+		return 0, err
+	}
+	return len(resp.Results), nil
+}
+
+func TestFakes(t *testing.T) {
+	feed := &streamtest.FlatFeed{
+		GetActivitiesFunc: func(_ context.Context, opts ...stream.GetActivitiesOption) (*stream.FlatFeedResponse, error) {
+			return &stream.FlatFeedResponse{Results: []stream.Activity{{ID: "a1"}, {ID: "a2"}}}, nil
+		},
+	}
+	var slug, userID string
+	client := &streamtest.Client{
+		FlatFeedFunc: func(s, id string) (stream.FlatFeedInterface, error) {
+			slug, userID = s, id
+			return feed, nil
+		},
+	}
+
+	n, err := countActivities(context.Background(), client, "john")
+	require.NoError(t, err)
+	assert.Equal(t, 2, n)
+	assert.Equal(t, "user", slug)
+	assert.Equal(t, "john", userID)
+
+	assert.PanicsWithValue(t, "streamtest: FlatFeed.ID not implemented", func() { feed.ID() })
+}
@@ -0,0 +1,157 @@
+//go:build ignore
+
+// gen generates the fakes of the stream interfaces. Run it with go generate.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"log"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+const streamPkg = "github.com/GetStream/stream-go2/v8"
+
+type fake struct {
+	name  string
+	iface reflect.Type
+}
+
+var fakes = []fake{
+	{"Client", reflect.TypeOf((*stream.ClientInterface)(nil)).Elem()},
+	{"Feed", reflect.TypeOf((*stream.Feed)(nil)).Elem()},
+	{"FlatFeed", reflect.TypeOf((*stream.FlatFeedInterface)(nil)).Elem()},
+	{"AggregatedFeed", reflect.TypeOf((*stream.AggregatedFeedInterface)(nil)).Elem()},
+	{"NotificationFeed", reflect.TypeOf((*stream.NotificationFeedInterface)(nil)).Elem()},
+	{"ReactionsClient", reflect.TypeOf((*stream.ReactionsClientInterface)(nil)).Elem()},
+	{"CollectionsClient", reflect.TypeOf((*stream.CollectionsClientInterface)(nil)).Elem()},
+	{"UsersClient", reflect.TypeOf((*stream.UsersClientInterface)(nil)).Elem()},
+	{"ModerationClient", reflect.TypeOf((*stream.ModerationClientInterface)(nil)).Elem()},
+	{"AnalyticsClient", reflect.TypeOf((*stream.AnalyticsClientInterface)(nil)).Elem()},
+	{"PersonalizationClient", reflect.TypeOf((*stream.PersonalizationClientInterface)(nil)).Elem()},
+	{"AuditLogsClient", reflect.TypeOf((*stream.AuditLogsClientInterface)(nil)).Elem()},
+}
+
+func main() {
+	imports := map[string]bool{}
+	var body bytes.Buffer
+	for _, f := range fakes {
+		writeFake(&body, f, imports)
+	}
+
+	var out bytes.Buffer
+	out.WriteString("// Code generated by gen.go; DO NOT EDIT.\n\npackage streamtest\n\nimport (\n")
+	paths := make([]string, 0, len(imports))
+	for path := range imports {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		if path != streamPkg {
+			fmt.Fprintf(&out, "\t%q\n", path)
+		}
+	}
+	fmt.Fprintf(&out, "\n\tstream %q\n)\n\nvar (\n", streamPkg)
+	for _, f := range fakes {
+		fmt.Fprintf(&out, "\t_ stream.%s = (*%s)(nil)\n", typeName(f.iface), f.name)
+	}
+	out.WriteString(")\n")
+	out.Write(body.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile("fakes.go", src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func typeName(t reflect.Type) string {
+	return strings.TrimPrefix(t.String(), "stream.")
+}
+
+func writeFake(w *bytes.Buffer, f fake, imports map[string]bool) {
+	fmt.Fprintf(w, "\n// %s is a fake stream.%s. Each method calls the function\n", f.name, typeName(f.iface))
+	fmt.Fprintf(w, "// field having the same name and the Func suffix, and panics if it is nil.\n")
+	fmt.Fprintf(w, "type %s struct {\n", f.name)
+	for i := 0; i < f.iface.NumMethod(); i++ {
+		m := f.iface.Method(i)
+		fmt.Fprintf(w, "\t%sFunc func%s\n", m.Name, signature(m.Type, false, imports))
+	}
+	w.WriteString("}\n")
+
+	for i := 0; i < f.iface.NumMethod(); i++ {
+		m := f.iface.Method(i)
+		args := make([]string, m.Type.NumIn())
+		for j := range args {
+			args[j] = fmt.Sprintf("a%d", j)
+			if m.Type.IsVariadic() && j == len(args)-1 {
+				args[j] += "..."
+			}
+		}
+		fmt.Fprintf(w, "\n// %s calls %sFunc.\n", m.Name, m.Name)
+		fmt.Fprintf(w, "func (f *%s) %s%s {\n", f.name, m.Name, signature(m.Type, true, imports))
+		fmt.Fprintf(w, "\tif f.%sFunc == nil {\n", m.Name)
+		fmt.Fprintf(w, "\t\tpanic(\"streamtest: %s.%s not implemented\")\n\t}\n", f.name, m.Name)
+		ret := ""
+		if m.Type.NumOut() > 0 {
+			ret = "return "
+		}
+		fmt.Fprintf(w, "\t%sf.%sFunc(%s)\n}\n", ret, m.Name, strings.Join(args, ", "))
+	}
+}
+
+func signature(t reflect.Type, named bool, imports map[string]bool) string {
+	in := make([]string, t.NumIn())
+	for i := range in {
+		var s string
+		if t.IsVariadic() && i == len(in)-1 {
+			s = "..." + typeString(t.In(i).Elem(), imports)
+		} else {
+			s = typeString(t.In(i), imports)
+		}
+		if named {
+			s = fmt.Sprintf("a%d %s", i, s)
+		}
+		in[i] = s
+	}
+	out := make([]string, t.NumOut())
+	for i := range out {
+		out[i] = typeString(t.Out(i), imports)
+	}
+	s := "(" + strings.Join(in, ", ") + ")"
+	switch len(out) {
+	case 0:
+	case 1:
+		s += " " + out[0]
+	default:
+		s += " (" + strings.Join(out, ", ") + ")"
+	}
+	return s
+}
+
+func typeString(t reflect.Type, imports map[string]bool) string {
+	collectImports(t, imports)
+	return strings.ReplaceAll(t.String(), "interface {}", "any")
+}
+
+func collectImports(t reflect.Type, imports map[string]bool) {
+	if t.PkgPath() != "" {
+		imports[t.PkgPath()] = true
+		return
+	}
+	switch t.Kind() {
+	case reflect.Pointer, reflect.Slice, reflect.Array:
+		collectImports(t.Elem(), imports)
+	case reflect.Map:
+		collectImports(t.Key(), imports)
+		collectImports(t.Elem(), imports)
+	}
+}
@@ -0,0 +1,214 @@
+package stream
+
+import (
+	"context"
+	"sort"
+)
+
+// SyncFollowsOption configures SyncFollows.
+type SyncFollowsOption func(*syncFollowsOptions)
+
+type syncFollowsOptions struct {
+	dryRun            bool
+	keepHistory       bool
+	activityCopyLimit *int
+	slugs             map[string]bool
+	batch             []BatchOption
+}
+
+// WithSyncDryRun computes the changes without applying them.
+func WithSyncDryRun() SyncFollowsOption {
+	return func(o *syncFollowsOptions) {
+		o.dryRun = true
+	}
+}
+
+// WithSyncKeepHistory keeps the activities of the unfollowed feeds in the
+// source feed.
+func WithSyncKeepHistory() SyncFollowsOption {
+	return func(o *syncFollowsOptions) {
+		o.keepHistory = true
+	}
+}
+
+// WithSyncActivityCopyLimit sets how many activities are copied from the
+// newly followed feeds.
+func WithSyncActivityCopyLimit(limit int) SyncFollowsOption {
+	return func(o *syncFollowsOptions) {
+		o.activityCopyLimit = &limit
+	}
+}
+
+// WithSyncSlugs restricts the reconciliation to the followed feeds of the
+// given groups: follows of other groups are left untouched.
+func WithSyncSlugs(slugs ...string) SyncFollowsOption {
+	return func(o *syncFollowsOptions) {
+		if o.slugs == nil {
+			o.slugs = make(map[string]bool, len(slugs))
+		}
+		for _, s := range slugs {
+			o.slugs[s] = true
+		}
+	}
+}
+
+// WithSyncBatchOptions sets the options of the follow and unfollow batches.
+func WithSyncBatchOptions(opts ...BatchOption) SyncFollowsOption {
+	return func(o *syncFollowsOptions) {
+		o.batch = append(o.batch, opts...)
+	}
+}
+
+// SyncFollowsReport describes the changes made by SyncFollows to the follows
+// of a source feed.
+type SyncFollowsReport struct {
+	Source FeedID
+	// Followed lists the feeds which were not followed and are desired.
+	Followed []FeedID
+	// Unfollowed lists the feeds which were followed and are not desired.
+	Unfollowed []FeedID
+	// Unchanged is the number of desired feeds which were already followed.
+	Unchanged int
+	// DryRun tells whether the changes were only computed.
+	DryRun bool
+	// Follows and Unfollows report the outcome of every change, if applied.
+	Follows   *BatchResult
+	Unfollows *BatchResult
+	// Err is the error which stopped the synchronization, if any.
+	Err error
+}
+
+// SyncFollows makes the source feed follow exactly the desired feeds: it
+// pages through the currently followed feeds, then follows the missing ones
+// and unfollows the undesired ones in batches. It fails with
+// ErrTooManyFollows if source follows more feeds than the API can page
+// through.
+func (c *Client) SyncFollows(ctx context.Context, source Feed, desired []FeedID, opts ...SyncFollowsOption) (*SyncFollowsReport, error) {
+	o := syncFollowsOptions{}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	report := &SyncFollowsReport{Source: FeedIDOf(source), DryRun: o.dryRun}
+	err := c.syncFollows(ctx, source, desired, o, report)
+	report.Err = err
+	return report, err
+}
+
+func (c *Client) syncFollows(ctx context.Context, source Feed, desired []FeedID, o syncFollowsOptions, report *SyncFollowsReport) error {
+	current, err := c.currentFollows(ctx, source, o)
+	if err != nil {
+		return err
+	}
+
+	wanted := make(map[FeedID]bool, len(desired))
+	for _, id := range desired {
+		if (o.slugs != nil && !o.slugs[id.Slug]) || wanted[id] {
+			continue
+		}
+		wanted[id] = true
+		if current[id] {
+			report.Unchanged++
+		} else {
+			report.Followed = append(report.Followed, id)
+		}
+	}
+	for id := range current {
+		if !wanted[id] {
+			report.Unfollowed = append(report.Unfollowed, id)
+		}
+	}
+	sortFeedIDs(report.Unfollowed)
+	if o.dryRun {
+		return nil
+	}
+
+	if len(report.Followed) > 0 {
+		follows := make([]FollowRelationship, len(report.Followed))
+		for i, id := range report.Followed {
+			follows[i] = NewFollowRelationshipFromIDs(report.Source, id)
+			follows[i].ActivityCopyLimit = o.activityCopyLimit
+		}
+		report.Follows, err = c.FollowManyBatched(ctx, follows, o.batch...)
+		if err != nil {
+			return err
+		}
+	}
+	if len(report.Unfollowed) > 0 {
+		unfollows := make([]UnfollowRelationship, len(report.Unfollowed))
+		for i, id := range report.Unfollowed {
+			unfollows[i] = NewUnfollowRelationshipFromIDs(report.Source, id)
+			unfollows[i].KeepHistory = o.keepHistory
+		}
+		report.Unfollows, err = c.UnfollowManyBatched(ctx, unfollows, o.batch...)
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// currentFollows returns the feeds followed by source, within the slugs of
+// the options if any.
+func (c *Client) currentFollows(ctx context.Context, source Feed, o syncFollowsOptions) (map[FeedID]bool, error) {
+	current := make(map[FeedID]bool)
+	pager := c.followingPager(source)
+	pager.fetch = func(ctx context.Context, limit, offset int) ([]Follower, error) {
+		resp, err := source.GetFollowing(ctx, WithFollowingLimit(limit), WithFollowingOffset(offset))
+		if err != nil {
+			return nil, err
+		}
+		return resp.Results, nil
+	}
+	err := pageFollows(ctx, pager, func(f Follower) error {
+		id, err := f.Target()
+		if err != nil {
+			return err
+		}
+		if o.slugs == nil || o.slugs[id.Slug] {
+			current[id] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return current, nil
+}
+
+// SyncFollowsMany runs SyncFollows for every source feed of desired, one at
+// a time. It returns the reports sorted by source, and the first error.
+func (c *Client) SyncFollowsMany(ctx context.Context, desired map[FeedID][]FeedID, opts ...SyncFollowsOption) ([]*SyncFollowsReport, error) {
+	sources := make([]FeedID, 0, len(desired))
+	for id := range desired {
+		sources = append(sources, id)
+	}
+	sortFeedIDs(sources)
+
+	var (
+		reports  = make([]*SyncFollowsReport, 0, len(sources))
+		firstErr error
+	)
+	for _, id := range sources {
+		if err := ctx.Err(); err != nil {
+			return reports, err
+		}
+		var report *SyncFollowsReport
+		feed, err := c.GenericFeed(id.String())
+		if err != nil {
+			report = &SyncFollowsReport{Source: id, Err: err}
+		} else {
+			report, err = c.SyncFollows(ctx, feed, desired[id], opts...)
+		}
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+		reports = append(reports, report)
+	}
+	return reports, firstErr
+}
+
+func sortFeedIDs(ids []FeedID) {
+	sort.Slice(ids, func(i, j int) bool {
+		return ids[i].String() < ids[j].String()
+	})
+}
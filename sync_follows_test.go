@@ -0,0 +1,185 @@
+package stream_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+// followGraph is a fake follow graph backend.
+type followGraph struct {
+	mu      sync.Mutex
+	follows map[string]map[string]bool
+	pages   int
+	stats   int
+	keep    []bool
+
+	imported   []stream.FollowRelationship
+	failImport string
+}
+
+func (g *followGraph) following(source string) []string {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var targets []string
+	for t := range g.follows[source] {
+		targets = append(targets, t)
+	}
+	sort.Strings(targets)
+	return targets
+}
+
+func (g *followGraph) Do(req *http.Request) (*http.Response, error) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	var body []byte
+	if req.Body != nil {
+		body, _ = io.ReadAll(req.Body)
+	}
+	limit, _ := strconv.Atoi(req.URL.Query().Get("limit"))
+	offset, _ := strconv.Atoi(req.URL.Query().Get("offset"))
+	if req.Method == http.MethodGet && (limit > 500 || offset > 400) {
+		return jsonResponse(http.StatusBadRequest, `{"detail":"limit or offset too large","status_code":400}`), nil
+	}
+	switch {
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/follows/"):
+		g.pages++
+		parts := strings.Split(req.URL.Path, "/")
+		source := parts[len(parts)-4] + ":" + parts[len(parts)-3]
+		var targets []string
+		for t := range g.follows[source] {
+			targets = append(targets, t)
+		}
+		sort.Strings(targets)
+		results := []stream.Follower{}
+		for i := offset; i < len(targets) && i < offset+limit; i++ {
+			results = append(results, stream.Follower{FeedID: source, TargetID: targets[i]})
+		}
+		b, _ := json.Marshal(map[string]any{"results": results})
+		return jsonResponse(http.StatusOK, string(b)), nil
+	case req.Method == http.MethodGet && strings.HasSuffix(req.URL.Path, "/followers/"):
+		parts := strings.Split(req.URL.Path, "/")
+		target := parts[len(parts)-4] + ":" + parts[len(parts)-3]
+		var sources []string
+		for s, targets := range g.follows {
+			if targets[target] {
+				sources = append(sources, s)
+			}
+		}
+		sort.Strings(sources)
+		results := []stream.Follower{}
+		for i := offset; i < len(sources) && (limit == 0 || i < offset+limit); i++ {
+			results = append(results, stream.Follower{FeedID: sources[i], TargetID: target})
+		}
+		b, _ := json.Marshal(map[string]any{"results": results})
+		return jsonResponse(http.StatusOK, string(b)), nil
+	case strings.HasSuffix(req.URL.Path, "/stats/follow/"):
+		g.stats++
+		following := req.URL.Query().Get("following")
+		followers := 0
+		for _, targets := range g.follows {
+			if targets[req.URL.Query().Get("followers")] {
+				followers++
+			}
+		}
+		b, _ := json.Marshal(map[string]any{"results": map[string]any{
+			"following": map[string]any{"feed": following, "count": len(g.follows[following])},
+			"followers": map[string]any{"feed": req.URL.Query().Get("followers"), "count": followers},
+		}})
+		return jsonResponse(http.StatusOK, string(b)), nil
+	case strings.HasSuffix(req.URL.Path, "/follow_many/"):
+		var rels []stream.FollowRelationship
+		_ = json.Unmarshal(body, &rels)
+		g.imported = append(g.imported, rels...)
+		if g.failImport != "" && len(rels) > 0 && rels[0].Target == g.failImport {
+			return jsonResponse(http.StatusInternalServerError, `{"detail":"oops","status_code":500}`), nil
+		}
+		for _, r := range rels {
+			if g.follows[r.Source] == nil {
+				g.follows[r.Source] = make(map[string]bool)
+			}
+			g.follows[r.Source][r.Target] = true
+		}
+	case strings.HasSuffix(req.URL.Path, "/unfollow_many/"):
+		var rels []stream.UnfollowRelationship
+		_ = json.Unmarshal(body, &rels)
+		for _, r := range rels {
+			delete(g.follows[r.Source], r.Target)
+			g.keep = append(g.keep, r.KeepHistory)
+		}
+	}
+	return jsonResponse(http.StatusCreated, `{}`), nil
+}
+
+func TestSyncFollows(t *testing.T) {
+	graph := &followGraph{follows: map[string]map[string]bool{"timeline:1": {}}}
+	for i := 0; i < 600; i++ {
+		graph.follows["timeline:1"][fmt.Sprint("user:", i)] = true
+	}
+	graph.follows["timeline:1"]["page:1"] = true
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(graph))
+	require.NoError(t, err)
+	ctx := context.Background()
+	timeline, err := client.FlatFeed("timeline", "1")
+	require.NoError(t, err)
+
+	var desired []stream.FeedID
+	for i := 100; i < 700; i++ {
+		desired = append(desired, stream.FeedID{Slug: "user", UserID: fmt.Sprint(i)})
+	}
+	desired = append(desired, desired[0])
+
+	report, err := client.SyncFollows(ctx, timeline, desired, stream.WithSyncDryRun(), stream.WithSyncSlugs("user"))
+	require.NoError(t, err)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 2, graph.pages)
+	assert.Len(t, report.Followed, 100)
+	assert.Len(t, report.Unfollowed, 100)
+	assert.Equal(t, 500, report.Unchanged)
+	assert.Len(t, graph.following("timeline:1"), 601)
+
+	report, err = client.SyncFollows(ctx, timeline, desired, stream.WithSyncSlugs("user"), stream.WithSyncKeepHistory())
+	require.NoError(t, err)
+	assert.Len(t, report.Follows.Succeeded(), 100)
+	assert.Len(t, report.Unfollows.Succeeded(), 100)
+	following := graph.following("timeline:1")
+	assert.Len(t, following, 601)
+	assert.Contains(t, following, "page:1")
+	assert.Contains(t, following, "user:699")
+	assert.NotContains(t, following, "user:99")
+	assert.Equal(t, []bool{true}, graph.keep[:1])
+
+	report, err = client.SyncFollows(ctx, timeline, nil)
+	require.NoError(t, err)
+	assert.Len(t, report.Unfollowed, 601)
+	assert.Empty(t, graph.following("timeline:1"))
+}
+
+func TestSyncFollowsMany(t *testing.T) {
+	graph := &followGraph{follows: map[string]map[string]bool{"timeline:1": {"user:1": true}}}
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(graph))
+	require.NoError(t, err)
+
+	reports, err := client.SyncFollowsMany(context.Background(), map[stream.FeedID][]stream.FeedID{
+		stream.MustParseFeedID("timeline:2"): {stream.MustParseFeedID("user:1")},
+		stream.MustParseFeedID("timeline:1"): {stream.MustParseFeedID("user:2")},
+	})
+	require.NoError(t, err)
+	require.Len(t, reports, 2)
+	assert.Equal(t, "timeline:1", reports[0].Source.String())
+	assert.Equal(t, []stream.FeedID{stream.MustParseFeedID("user:1")}, reports[0].Unfollowed)
+	assert.Equal(t, []string{"user:2"}, graph.following("timeline:1"))
+	assert.Equal(t, []string{"user:1"}, graph.following("timeline:2"))
+}
@@ -9,8 +9,6 @@ import (
 	"strconv"
 	"strings"
 	"time"
-
-	"github.com/mitchellh/mapstructure"
 )
 
 // Duration wraps time.Duration, used because of JSON marshaling and
@@ -84,27 +82,43 @@ type Data struct {
 	Extra map[string]any `json:"-"`
 }
 
-func (a *Data) decode(data map[string]any) error {
-	// We are not using decodeData here because we do not need the DecodeHook
-	// since it leads to a stack overflow
-	cfg := &mapstructure.DecoderConfig{
-		Result:   a,
-		Metadata: &mapstructure.Metadata{},
-		TagName:  "json",
-	}
-	dec, err := mapstructure.NewDecoder(cfg)
-	if err != nil {
+// UnmarshalJSON decodes the provided JSON payload into the Data. The payload can
+// be either a plain reference string or an enriched object.
+func (a *Data) UnmarshalJSON(b []byte) error {
+	var v any
+	if err := json.Unmarshal(b, &v); err != nil {
 		return err
 	}
-	if err := dec.Decode(data); err != nil {
-		return err
+	switch v := v.(type) {
+	case nil:
+		return nil
+	case string:
+		*a = Data{ID: v}
+		return nil
+	case map[string]any:
+		return a.decode(v)
+	default:
+		return errors.New("invalid data")
 	}
+}
 
-	if len(cfg.Metadata.Unused) > 0 {
-		a.Extra = make(map[string]any)
-		for _, k := range cfg.Metadata.Unused {
-			a.Extra[k] = data[k]
+func (a *Data) decode(data map[string]any) error {
+	for k, v := range data {
+		if k == "id" {
+			if v == nil {
+				continue
+			}
+			id, ok := v.(string)
+			if !ok {
+				return fmt.Errorf("invalid data id: %v", v)
+			}
+			a.ID = id
+			continue
 		}
+		if a.Extra == nil {
+			a.Extra = make(map[string]any)
+		}
+		a.Extra[k] = v
 	}
 	return nil
 }
@@ -157,6 +171,16 @@ type response struct {
 	Duration Duration `json:"duration,omitempty"`
 }
 
+// rateSetter is implemented by responses which carry the rate limit
+// information taken from the response headers.
+type rateSetter interface {
+	setRate(*Rate)
+}
+
+func (r *response) setRate(rate *Rate) {
+	r.Rate = *rate
+}
+
 type BaseResponse struct {
 	response
 }
@@ -338,7 +362,6 @@ func (a *activityResponse) UnmarshalJSON(buf []byte) error {
 		return err
 	}
 	delete(ac.Extra, "duration")
-	delete(ac.Extra, "ratelimit")
 	*a = activityResponse{response: r, Activity: ac}
 	return nil
 }
@@ -363,6 +386,16 @@ type Follower struct {
 	TargetID string `json:"target_id,omitempty"`
 }
 
+// Feed parses the ID of the following feed.
+func (f Follower) Feed() (FeedID, error) {
+	return ParseFeedID(f.FeedID)
+}
+
+// Target parses the ID of the followed feed.
+func (f Follower) Target() (FeedID, error) {
+	return ParseFeedID(f.TargetID)
+}
+
 // followResponse is the API response obtained when retrieving follow graph
 type followResponse struct {
 	response
@@ -409,6 +442,19 @@ func NewFollowRelationship(source, target Feed, opts ...FollowRelationshipOption
 	return r
 }
 
+// NewFollowRelationshipFromIDs is a helper for creating a FollowRelationship
+// from the source ("follower") and target ("following") feed IDs.
+func NewFollowRelationshipFromIDs(source, target FeedID, opts ...FollowRelationshipOption) FollowRelationship {
+	r := FollowRelationship{
+		Source: source.String(),
+		Target: target.String(),
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
 // FollowRelationshipOption customizes a FollowRelationship.
 type FollowRelationshipOption func(r *FollowRelationship)
 
@@ -463,6 +509,20 @@ func NewUnfollowRelationship(source, target Feed, opts ...UnfollowRelationshipOp
 	return r
 }
 
+// NewUnfollowRelationshipFromIDs is a helper for creating an
+// UnfollowRelationship from the source ("follower") and target ("following")
+// feed IDs.
+func NewUnfollowRelationshipFromIDs(source, target FeedID, opts ...UnfollowRelationshipOption) UnfollowRelationship {
+	r := UnfollowRelationship{
+		Source: source.String(),
+		Target: target.String(),
+	}
+	for _, opt := range opts {
+		opt(&r)
+	}
+	return r
+}
+
 // WithUnfollowRelationshipKeepHistory sets the KeepHistory field for a given UnfollowRelationship.
 func WithUnfollowRelationshipKeepHistory() UnfollowRelationshipOption {
 	return func(r *UnfollowRelationship) {
@@ -577,6 +637,11 @@ type AddReactionRequestObject struct {
 	ParentID             string         `json:"parent,omitempty"`
 }
 
+// AddTargetFeeds adds the given feeds to the target feeds of the reaction.
+func (r *AddReactionRequestObject) AddTargetFeeds(ids ...FeedID) {
+	r.TargetFeeds = append(r.TargetFeeds, FeedIDStrings(ids...)...)
+}
+
 // filterResponse is the part of StreamAPI responses common for FilterReactions API requests.
 type filterResponse struct {
 	response
@@ -671,6 +736,10 @@ type PersonalizationResponse struct {
 	extra    map[string]any
 }
 
+func (r *PersonalizationResponse) setRate(rate *Rate) {
+	r.Rate = *rate
+}
+
 // Extra returns the non-common response fields as a map[string]any.
 func (r *PersonalizationResponse) Extra() map[string]any {
 	return r.extra
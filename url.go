@@ -50,13 +50,23 @@ func (u regionalURLBuilder) makeVersion() string {
 }
 
 func (u regionalURLBuilder) makeRegion(subdomain string) string {
-	if u.region != "" {
-		if override, ok := regionOverrides[u.region]; ok {
-			return override
-		}
-		return u.region
+	return regionSubdomain(u.region, subdomain, nil)
+}
+
+// regionSubdomain returns the subdomain for the given region, looking it up in
+// the extra overrides first and in the built-in ones then. The fallback
+// subdomain is used when no region is set.
+func regionSubdomain(region, fallback string, extra map[string]string) string {
+	if region == "" {
+		return fallback
+	}
+	if override, ok := extra[region]; ok {
+		return override
+	}
+	if override, ok := regionOverrides[region]; ok {
+		return override
 	}
-	return subdomain
+	return region
 }
 
 type apiURLBuilder struct {
@@ -89,8 +99,18 @@ func (b personalizationURLBuilder) url() string {
 	if envHost := os.Getenv("STREAM_URL"); envHost != "" {
 		return envHost
 	}
+	return personalizationURL(b.region, nil)
+}
+
+// personalizationURL returns the personalization URL for the given region,
+// looking it up in the extra overrides first and in the built-in ones then.
+func personalizationURL(region string, extra map[string]string) string {
 	defaultPath := fmt.Sprintf("personalization.%s/personalization/v1.0/", domain)
-	if override, ok := personalizationOverrides[b.region]; ok {
+	override, ok := extra[region]
+	if !ok {
+		override, ok = personalizationOverrides[region]
+	}
+	if ok {
 		return fmt.Sprintf("https://%s-%s", override, defaultPath)
 	}
 
@@ -0,0 +1,198 @@
+package stream
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Files of a user data archive, besides the manifest.
+const (
+	UserDataManifestFile    = "manifest.json"
+	UserDataUserFile        = "user.json"
+	UserDataActivitiesFile  = "activities.jsonl"
+	UserDataReactionsFile   = "reactions.jsonl"
+	UserDataFollowsFile     = "follows.jsonl"
+	UserDataCollectionsFile = "collections.jsonl"
+)
+
+// UserDataManifest describes the content of a user data archive. It's
+// written to the archive as manifest.json.
+type UserDataManifest struct {
+	UserID    string         `json:"user_id"`
+	CreatedAt time.Time      `json:"created_at"`
+	Feeds     []FeedID       `json:"feeds"`
+	Files     []UserDataFile `json:"files"`
+}
+
+// UserDataFile is a file of a user data archive.
+type UserDataFile struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Count is the number of records in the file.
+	Count int `json:"count"`
+}
+
+// UserDataCollectionObject is a line of the collections file of a user data
+// archive.
+type UserDataCollectionObject struct {
+	Collection string `json:"collection"`
+	GetCollectionResponseObject
+}
+
+// archiveWriter is the destination of a user data archive.
+type archiveWriter interface {
+	create(name string) (io.Writer, error)
+	close() error
+}
+
+type dirArchive struct {
+	dir  string
+	file *os.File
+	buf  *bufio.Writer
+}
+
+func (a *dirArchive) create(name string) (io.Writer, error) {
+	if err := a.close(); err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filepath.Join(a.dir, name), os.O_CREATE|os.O_TRUNC|os.O_WRONLY, 0o600)
+	if err != nil {
+		return nil, err
+	}
+	a.file, a.buf = f, bufio.NewWriter(f)
+	return a.buf, nil
+}
+
+func (a *dirArchive) close() error {
+	if a.file == nil {
+		return nil
+	}
+	f := a.file
+	a.file = nil
+	if err := a.buf.Flush(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+type zipArchive struct {
+	zw *zip.Writer
+}
+
+func (a zipArchive) create(name string) (io.Writer, error) {
+	return a.zw.Create(name)
+}
+
+func (a zipArchive) close() error {
+	return a.zw.Close()
+}
+
+// ExportUserData writes all the data of a user to the given directory, which
+// is created if missing: their user record, the activities of their feeds,
+// their reactions, the follows from and to their feeds and the collection
+// objects listed by the plan. Every file is described by manifest.json,
+// which is written last and returned.
+func (c *Client) ExportUserData(ctx context.Context, userID string, plan ErasurePlan, dir string) (*UserDataManifest, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	a := &dirArchive{dir: dir}
+	manifest, err := c.exportUserData(ctx, userID, plan, a)
+	if cerr := a.close(); err == nil {
+		err = cerr
+	}
+	return manifest, err
+}
+
+// ExportUserDataZip is like ExportUserData, writing the files to a zip
+// archive instead.
+func (c *Client) ExportUserDataZip(ctx context.Context, userID string, plan ErasurePlan, w io.Writer) (*UserDataManifest, error) {
+	a := zipArchive{zw: zip.NewWriter(w)}
+	manifest, err := c.exportUserData(ctx, userID, plan, a)
+	if cerr := a.close(); err == nil {
+		err = cerr
+	}
+	return manifest, err
+}
+
+func (c *Client) exportUserData(ctx context.Context, userID string, plan ErasurePlan, a archiveWriter) (*UserDataManifest, error) {
+	if userID == "" {
+		return nil, errInvalidUserID
+	}
+	manifest := &UserDataManifest{UserID: userID, CreatedAt: time.Now().UTC()}
+	for _, slug := range plan.Feeds {
+		id, err := NewFeedID(slug, userID)
+		if err != nil {
+			return nil, err
+		}
+		manifest.Feeds = append(manifest.Feeds, id)
+	}
+
+	files := []struct {
+		UserDataFile
+		write func(w io.Writer) (int, error)
+	}{
+		{UserDataFile{Name: UserDataUserFile, Description: "User record"}, func(w io.Writer) (int, error) {
+			user, err := c.Users().Get(ctx, userID)
+			if isNotFound(err) {
+				_, err = io.WriteString(w, "null\n")
+				return 0, err
+			} else if err != nil {
+				return 0, err
+			}
+			return 1, json.NewEncoder(w).Encode(user.User)
+		}},
+		{UserDataFile{Name: UserDataActivitiesFile, Description: "Activities of the user's feeds"}, func(w io.Writer) (int, error) {
+			return c.ExportActivities(ctx, w, manifest.Feeds)
+		}},
+		{UserDataFile{Name: UserDataReactionsFile, Description: "Reactions of the user"}, func(w io.Writer) (int, error) {
+			enc := json.NewEncoder(w)
+			n := 0
+			err := c.pageUserReactions(ctx, userID, true, func(r Reaction) error {
+				n++
+				return enc.Encode(r)
+			})
+			return n, err
+		}},
+		{UserDataFile{Name: UserDataFollowsFile, Description: "Follow relationships from and to the user's feeds"}, func(w io.Writer) (int, error) {
+			return c.ExportFollows(ctx, w, manifest.Feeds, WithExportFollowing(), WithExportFollowers())
+		}},
+		{UserDataFile{Name: UserDataCollectionsFile, Description: "Collection objects of the user"}, func(w io.Writer) (int, error) {
+			enc := json.NewEncoder(w)
+			n := 0
+			err := c.pageCollectionObjects(ctx, plan.Collections, func(name string, obj GetCollectionResponseObject) error {
+				n++
+				return enc.Encode(UserDataCollectionObject{Collection: name, GetCollectionResponseObject: obj})
+			})
+			return n, err
+		}},
+	}
+	for _, f := range files {
+		w, err := a.create(f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if f.Count, err = f.write(w); err != nil {
+			return nil, err
+		}
+		manifest.Files = append(manifest.Files, f.UserDataFile)
+	}
+
+	w, err := a.create(UserDataManifestFile)
+	if err != nil {
+		return nil, err
+	}
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(manifest); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
@@ -0,0 +1,90 @@
+package stream_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	stream "github.com/GetStream/stream-go2/v8"
+)
+
+func TestExportUserData(t *testing.T) {
+	data := newUserData()
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(data))
+	require.NoError(t, err)
+
+	dir := filepath.Join(t.TempDir(), "export")
+	manifest, err := client.ExportUserData(context.Background(), "u1", erasurePlan, dir)
+	require.NoError(t, err)
+	assert.Equal(t, "u1", manifest.UserID)
+	assert.Equal(t, []stream.FeedID{stream.MustParseFeedID("user:u1"), stream.MustParseFeedID("timeline:u1")}, manifest.Feeds)
+	counts := map[string]int{}
+	for _, f := range manifest.Files {
+		counts[f.Name] = f.Count
+		assert.NotEmpty(t, f.Description)
+	}
+	assert.Equal(t, map[string]int{
+		stream.UserDataUserFile:        1,
+		stream.UserDataActivitiesFile:  3,
+		stream.UserDataReactionsFile:   2,
+		stream.UserDataFollowsFile:     2,
+		stream.UserDataCollectionsFile: 2,
+	}, counts)
+
+	b, err := os.ReadFile(filepath.Join(dir, stream.UserDataManifestFile))
+	require.NoError(t, err)
+	var written stream.UserDataManifest
+	require.NoError(t, json.Unmarshal(b, &written))
+	assert.Equal(t, manifest.Files, written.Files)
+
+	b, err = os.ReadFile(filepath.Join(dir, stream.UserDataUserFile))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"id":"u1"}`, string(b))
+
+	b, err = os.ReadFile(filepath.Join(dir, stream.UserDataCollectionsFile))
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(b)), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"collection":"food","foreign_id":"food:1","data":null}`, lines[0])
+	assert.Zero(t, data.deletes)
+}
+
+func TestExportUserDataZip(t *testing.T) {
+	data := newUserData()
+	delete(data.users, "u1")
+	client, err := stream.New("key", "secret", stream.WithHTTPRequester(data))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	manifest, err := client.ExportUserDataZip(context.Background(), "u1", stream.ErasurePlan{Feeds: []string{"user"}}, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, 0, manifest.Files[0].Count)
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, err := f.Open()
+		require.NoError(t, err)
+		b, err := io.ReadAll(rc)
+		require.NoError(t, err)
+		rc.Close()
+		files[f.Name] = string(b)
+	}
+	assert.Len(t, files, 6)
+	assert.Equal(t, "null\n", files[stream.UserDataUserFile])
+	assert.Equal(t, 2, strings.Count(files[stream.UserDataActivitiesFile], "\n"))
+	assert.Contains(t, files[stream.UserDataManifestFile], `"user_id": "u1"`)
+
+	_, err = client.ExportUserDataZip(context.Background(), "", stream.ErasurePlan{}, &buf)
+	assert.Error(t, err)
+}
@@ -2,8 +2,8 @@ package stream
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
+	"net/http"
 )
 
 // UsersClient is a specialized client used to interact with the Users endpoints.
@@ -11,12 +11,9 @@ type UsersClient struct {
 	client *Client
 }
 
-func (c *UsersClient) decode(resp []byte, err error) (*UserResponse, error) {
-	if err != nil {
-		return nil, err
-	}
+func (c *UsersClient) do(ctx context.Context, method string, endpoint endpoint, data any) (*UserResponse, error) {
 	var result UserResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
+	if err := c.client.request(ctx, method, endpoint, data, &result, c.client.authenticator.usersAuth); err != nil {
 		return nil, err
 	}
 	return &result, nil
@@ -27,7 +24,7 @@ func (c *UsersClient) Add(ctx context.Context, user User, getOrCreate bool) (*Us
 	endpoint := c.client.makeEndpoint("user/")
 	endpoint.addQueryParam(makeRequestOption("get_or_create", getOrCreate))
 
-	return c.decode(c.client.post(ctx, endpoint, user, c.client.authenticator.usersAuth))
+	return c.do(ctx, http.MethodPost, endpoint, user)
 }
 
 // Update updates the user's data.
@@ -37,21 +34,25 @@ func (c *UsersClient) Update(ctx context.Context, id string, data map[string]any
 	reqData := map[string]any{
 		"data": data,
 	}
-	return c.decode(c.client.put(ctx, endpoint, reqData, c.client.authenticator.usersAuth))
+	return c.do(ctx, http.MethodPut, endpoint, reqData)
 }
 
 // Get retrieves a user having the given id.
 func (c *UsersClient) Get(ctx context.Context, id string) (*UserResponse, error) {
 	endpoint := c.client.makeEndpoint("user/%s/", id)
 
-	return c.decode(c.client.get(ctx, endpoint, nil, c.client.authenticator.usersAuth))
+	return c.do(ctx, http.MethodGet, endpoint, nil)
 }
 
 // Delete deletes a user having the given id.
 func (c *UsersClient) Delete(ctx context.Context, id string) (*BaseResponse, error) {
 	endpoint := c.client.makeEndpoint("user/%s/", id)
 
-	return decode(c.client.delete(ctx, endpoint, nil, c.client.authenticator.usersAuth))
+	var result BaseResponse
+	if err := c.client.delete(ctx, endpoint, nil, &result, c.client.authenticator.usersAuth); err != nil {
+		return nil, err
+	}
+	return &result, nil
 }
 
 // CreateReference returns a new reference string in the form SU:<id>.
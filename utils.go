@@ -1,6 +1,7 @@
 package stream
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -62,6 +63,104 @@ func decodeData(data map[string]any, target any) (*mapstructure.Metadata, error)
 	return cfg.Metadata, nil
 }
 
+// decodeObject walks the JSON object in b, calling fn for each key so that its
+// value can be decoded directly from dec.
+func decodeObject(b []byte, fn func(key string, dec *json.Decoder) error) error {
+	dec := json.NewDecoder(bytes.NewReader(b))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if delim, ok := tok.(json.Delim); !ok || delim != '{' {
+		return fmt.Errorf("invalid JSON object: unexpected %v", tok)
+	}
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("invalid JSON object key: %v", tok)
+		}
+		if err := fn(key, dec); err != nil {
+			return fmt.Errorf("cannot decode field %q: %w", key, err)
+		}
+	}
+	_, err = dec.Token()
+	return err
+}
+
+func decodeString(dec *json.Decoder) (string, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return "", err
+	}
+	switch v := tok.(type) {
+	case nil:
+		return "", nil
+	case string:
+		return v, nil
+	default:
+		return "", fmt.Errorf("expected string, got %v", tok)
+	}
+}
+
+func decodeFloat(dec *json.Decoder) (float64, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return 0, err
+	}
+	switch v := tok.(type) {
+	case nil:
+		return 0, nil
+	case float64:
+		return v, nil
+	default:
+		return 0, fmt.Errorf("expected number, got %v", tok)
+	}
+}
+
+func decodeTime(dec *json.Decoder) (Time, error) {
+	s, err := decodeString(dec)
+	if err != nil || s == "" {
+		return Time{}, err
+	}
+	return timeFromString(s)
+}
+
+// decodeToTargets decodes the "to" field of activities, which can contain
+// either plain feed IDs or [feed ID, token] pairs.
+func decodeToTargets(dec *json.Decoder) ([]string, error) {
+	var tos []any
+	if err := dec.Decode(&tos); err != nil {
+		return nil, err
+	}
+	if tos == nil {
+		return nil, nil
+	}
+	simpleTos := make([]string, len(tos))
+	for i := range tos {
+		switch to := tos[i].(type) {
+		case string:
+			simpleTos[i] = to
+		case []any:
+			if len(to) == 0 {
+				return nil, errors.New("invalid format for to targets")
+			}
+			s, ok := to[0].(string)
+			if !ok {
+				return nil, errors.New("invalid format for to targets")
+			}
+			simpleTos[i] = s
+		}
+	}
+	return simpleTos, nil
+}
+
 func parseIntValue(values url.Values, key string) (val int, exits bool, err error) {
 	v := values.Get(key)
 	if v == "" {
@@ -79,17 +178,6 @@ func parseBool(value string) bool {
 	return v != "" && v != "false" && v != "f" && v != "0"
 }
 
-func decode(resp []byte, err error) (*BaseResponse, error) {
-	if err != nil {
-		return nil, err
-	}
-	var result BaseResponse
-	if err := json.Unmarshal(resp, &result); err != nil {
-		return nil, err
-	}
-	return &result, nil
-}
-
 // set environment values and return a func to reset old values
 func resetEnv(values map[string]string) (func(), error) {
 	old := map[string]string{}
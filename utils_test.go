@@ -1,10 +1,14 @@
 package stream_test
 
 import (
+	"encoding/json"
+	"errors"
 	"io"
 	"math/rand"
 	"net/http"
+	"net/url"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -42,6 +46,124 @@ func (m *mockRequester) Do(req *http.Request) (*http.Response, error) {
 	}, nil
 }
 
+// recordedRequest is a request received by a recordingRequester.
+type recordedRequest struct {
+	method string
+	host   string
+	path   string
+	query  url.Values
+	header http.Header
+	body   []byte
+	// deadline is the deadline of the request context, if any.
+	deadline time.Time
+}
+
+// String formats the request as "METHOD path".
+func (r recordedRequest) String() string {
+	return r.method + " " + r.path
+}
+
+// decode unmarshals the JSON body of the request into v.
+func (r recordedRequest) decode(v any) error {
+	return json.Unmarshal(r.body, v)
+}
+
+// recordingRequester records the requests and answers them with respond, or
+// with an empty JSON object if nil. It's safe for concurrent use: respond is
+// called outside of its lock, with the number of the request starting at 1.
+type recordingRequester struct {
+	mu       sync.Mutex
+	requests []recordedRequest
+	respond  func(r recordedRequest, n int) (*http.Response, error)
+}
+
+// newRecordingClient returns a client sending its requests to a
+// recordingRequester answering with respond.
+func newRecordingClient(t *testing.T, respond func(recordedRequest, int) (*http.Response, error), opts ...stream.ClientOption) (*stream.Client, *recordingRequester) {
+	t.Helper()
+	requester := &recordingRequester{respond: respond}
+	client, err := stream.New("key", "secret", append([]stream.ClientOption{stream.WithHTTPRequester(requester)}, opts...)...)
+	require.NoError(t, err)
+	return client, requester
+}
+
+func (r *recordingRequester) Do(req *http.Request) (*http.Response, error) {
+	rec := recordedRequest{
+		method: req.Method,
+		host:   req.URL.Host,
+		path:   req.URL.Path,
+		query:  req.URL.Query(),
+		header: req.Header.Clone(),
+	}
+	if req.Body != nil {
+		rec.body, _ = io.ReadAll(req.Body)
+	}
+	rec.deadline, _ = req.Context().Deadline()
+	r.mu.Lock()
+	r.requests = append(r.requests, rec)
+	n := len(r.requests)
+	r.mu.Unlock()
+	if r.respond == nil {
+		return jsonResponse(http.StatusOK, `{}`), nil
+	}
+	return r.respond(rec, n)
+}
+
+// recorded returns the requests received so far.
+func (r *recordingRequester) recorded() []recordedRequest {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]recordedRequest(nil), r.requests...)
+}
+
+// paths returns the requests received so far as "METHOD path".
+func (r *recordingRequester) paths() []string {
+	var paths []string
+	for _, req := range r.recorded() {
+		paths = append(paths, req.String())
+	}
+	return paths
+}
+
+// reset forgets the requests received so far.
+func (r *recordingRequester) reset() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests = nil
+}
+
+// statusResponder answers the requests with the status codes returned by
+// status, or with connection errors for status 0.
+func statusResponder(status func(n int) int) func(recordedRequest, int) (*http.Response, error) {
+	return func(_ recordedRequest, n int) (*http.Response, error) {
+		code := status(n)
+		if code == 0 {
+			return nil, errors.New("connection refused")
+		}
+		return jsonResponse(code, `{}`), nil
+	}
+}
+
+// requesterFunc is a Requester calling the function.
+type requesterFunc func(*http.Request) (*http.Response, error)
+
+func (f requesterFunc) Do(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+// errorResponse is an API error response with the given status code.
+func errorResponse(code int, detail string) *http.Response {
+	b, _ := json.Marshal(map[string]any{"detail": detail, "status_code": code})
+	return jsonResponse(code, string(b))
+}
+
+func jsonResponse(code int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: code,
+		Body:       io.NopCloser(strings.NewReader(body)),
+	}
+}
+
 func testRequest(t *testing.T, req *http.Request, method, url, body string) {
 	assert.Equal(t, url, req.URL.String())
 	assert.Equal(t, method, req.Method)